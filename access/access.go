@@ -3,10 +3,12 @@ package access
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/adverax/metacrm.kernel/types"
+	"github.com/adverax/metacrm.kernel/types/convert"
 )
 
 // Getter is abstract property props
@@ -19,6 +21,11 @@ type Setter interface {
 	SetProperty(ctx context.Context, name string, value interface{}) error
 }
 
+// Deleter is abstract property remover
+type Deleter interface {
+	DeleteProperty(ctx context.Context, name string) error
+}
+
 type GetterFunc func(ctx context.Context, name string) (interface{}, error)
 
 func (fn GetterFunc) GetProperty(ctx context.Context, name string) (interface{}, error) {
@@ -45,6 +52,9 @@ type Reader interface {
 	GetString(ctx context.Context, name string, defVal string) (string, error)
 	GetDuration(ctx context.Context, name string, defVal time.Duration) (time.Duration, error)
 	GetJson(ctx context.Context, name string, defVal json.RawMessage) (json.RawMessage, error)
+	GetTime(ctx context.Context, name string, defVal time.Time) (time.Time, error)
+	GetStrings(ctx context.Context, name string, defVal []string) ([]string, error)
+	GetMap(ctx context.Context, name string, defVal map[string]interface{}) (map[string]interface{}, error)
 }
 
 // Writer is abstract typed property setter
@@ -55,6 +65,9 @@ type Writer interface {
 	SetString(ctx context.Context, name string, value string) error
 	SetDuration(ctx context.Context, name string, value time.Duration) error
 	SetJson(ctx context.Context, name string, value json.RawMessage) error
+	SetTime(ctx context.Context, name string, value time.Time) error
+	SetStrings(ctx context.Context, name string, value []string) error
+	SetMap(ctx context.Context, name string, value map[string]interface{}) error
 }
 
 type ReaderGetter interface {
@@ -75,17 +88,44 @@ type ReaderWriterEx interface {
 	Transaction(ctx context.Context, action func(ctx context.Context, rw ReaderWriter) error) error
 }
 
+// GetValue returns value of the key, converted into type T (see convert.As).
+// Returns types.ErrNoMatch for missing value and ErrWrongType for value, that can't be converted.
 func GetValue[T any](ctx context.Context, getter Getter, key string) (val T, err error) {
 	v, err := getter.GetProperty(ctx, key)
 	if err != nil {
 		return val, err
 	}
 
-	if vv, ok := v.(T); ok {
+	if v == nil {
+		return val, types.ErrNoMatch
+	}
+
+	if vv, ok := convert.As[T](v); ok {
 		return vv, nil
 	}
 
-	return val, fmt.Errorf("can't typecast %v as %v", v, val)
+	return val, fmt.Errorf("%w: can't convert %v (%T) into %T with key %q", ErrWrongType, v, v, val, key)
+}
+
+// GetValueOr is like GetValue, but returns default value, if value is missing.
+//
+//	hosts, err := access.GetValueOr(ctx, getter, "$.hosts", []string{"localhost"})
+func GetValueOr[T any](ctx context.Context, getter Getter, key string, defVal T) (T, error) {
+	val, err := GetValue[T](ctx, getter, key)
+	if errors.Is(err, types.ErrNoMatch) {
+		return defVal, nil
+	}
+	return val, err
+}
+
+// Must returns value or panics on error:
+//
+//	port := access.Must(access.GetValue[int](ctx, getter, "$.address.port"))
+func Must[T any](val T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+	return val
 }
 
 func GetValueFromContext[T any](ctx context.Context, key string) (val T, err error) {
@@ -0,0 +1,136 @@
+package access
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/adverax/metacrm.kernel/containers/maps"
+	"github.com/adverax/metacrm.kernel/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetValue(t *testing.T) {
+	ctx := context.Background()
+	data := maps.Map{
+		"port":    "42",
+		"timeout": "5s",
+		"debug":   "true",
+		"address": `{"host":"localhost"}`,
+		"name":    "My App",
+	}
+
+	port, err := GetValue[int](ctx, data, "port")
+	require.NoError(t, err)
+	assert.Equal(t, 42, port)
+
+	timeout, err := GetValue[time.Duration](ctx, data, "timeout")
+	require.NoError(t, err)
+	assert.Equal(t, 5*time.Second, timeout)
+
+	debug, err := GetValue[bool](ctx, data, "debug")
+	require.NoError(t, err)
+	assert.True(t, debug)
+
+	type address struct {
+		Host string `json:"host"`
+	}
+	addr, err := GetValue[address](ctx, data, "address")
+	require.NoError(t, err)
+	assert.Equal(t, address{Host: "localhost"}, addr)
+
+	_, err = GetValue[int](ctx, data, "name")
+	assert.ErrorIs(t, err, ErrWrongType)
+
+	_, err = GetValue[int](ctx, data, "unknown")
+	assert.ErrorIs(t, err, types.ErrNoMatch)
+
+	value, err := GetValueOr[int](ctx, data, "unknown", 80)
+	require.NoError(t, err)
+	assert.Equal(t, 80, value)
+
+	_, err = GetValueOr[int](ctx, data, "name", 80)
+	assert.ErrorIs(t, err, ErrWrongType)
+
+	assert.Equal(t, 42, Must(GetValue[int](ctx, data, "port")))
+	assert.Panics(t, func() {
+		Must(GetValue[int](ctx, data, "unknown"))
+	})
+}
+
+func TestReader(t *testing.T) {
+	ctx := context.Background()
+	data := maps.Map{
+		"started": "2024-03-01T10:00:00Z",
+		"date":    "2024-03-01",
+		"hosts":   []interface{}{"a", "b"},
+		"tags":    "x, y",
+		"limits":  map[string]interface{}{"cpu": 2},
+		"nested":  maps.Map{"a": 1},
+		"json":    `{"b":2}`,
+		"nil":     nil,
+	}
+	rw := NewSafeReaderWriter(NewReaderWriter(data))
+
+	started, err := rw.GetTime(ctx, "started", time.Time{})
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC), started)
+
+	date, err := rw.GetTime(ctx, "date", time.Time{})
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), date)
+
+	hosts, err := rw.GetStrings(ctx, "hosts", nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, hosts)
+
+	tags, err := rw.GetStrings(ctx, "tags", nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"x", "y"}, tags)
+
+	limits, err := rw.GetMap(ctx, "limits", nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"cpu": 2}, limits)
+
+	nested, err := rw.GetMap(ctx, "nested", nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"a": 1}, nested)
+
+	decoded, err := rw.GetMap(ctx, "json", nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"b": float64(2)}, decoded)
+
+	_, err = rw.GetTime(ctx, "hosts", time.Time{})
+	assert.Error(t, err)
+
+	defTime := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, name := range []string{"unknown", "nil"} {
+		value, err := rw.GetTime(ctx, name, defTime)
+		require.NoError(t, err)
+		assert.Equal(t, defTime, value)
+
+		list, err := rw.GetStrings(ctx, name, []string{"z"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"z"}, list)
+
+		m, err := rw.GetMap(ctx, name, map[string]interface{}{"z": 1})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"z": 1}, m)
+
+		port, err := GetValueOr(ctx, rw, name, 80)
+		require.NoError(t, err)
+		assert.Equal(t, 80, port)
+	}
+
+	require.NoError(t, rw.SetTime(ctx, "updated", defTime))
+	require.NoError(t, rw.SetStrings(ctx, "roles", []string{"admin"}))
+	require.NoError(t, rw.SetMap(ctx, "meta", map[string]interface{}{"k": "v"}))
+	assert.Equal(t, defTime, data["updated"])
+	assert.Equal(t, []string{"admin"}, data["roles"])
+	assert.Equal(t, map[string]interface{}{"k": "v"}, data["meta"])
+
+	roles, err := GetValueOr(ctx, rw, "roles", []string(nil))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"admin"}, roles)
+}
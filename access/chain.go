@@ -0,0 +1,148 @@
+package access
+
+import (
+	"context"
+	"errors"
+
+	"github.com/adverax/metacrm.kernel/types"
+)
+
+// SetPolicy decides, which layer of the chain receives writes.
+type SetPolicy int
+
+const (
+	// SetFirst writes into the first layer, that is Setter.
+	SetFirst SetPolicy = iota
+	// SetLast writes into the last layer, that is Setter.
+	SetLast
+	// SetOwner writes into the layer, that answers the name (or into the first Setter).
+	SetOwner
+	// SetAll writes into all layers, that are Setters.
+	SetAll
+	// SetNone forbids writes.
+	SetNone
+)
+
+// GetterChain resolves names through layers: the first layer, that knows the name, answers.
+// Error types.ErrNoMatch of the layer means "try the next layer".
+type GetterChain struct {
+	layers []Getter
+	policy SetPolicy
+}
+
+// Chain is constructor for build GetterChain. Layers are ordered by priority.
+func Chain(layers ...Getter) *GetterChain {
+	return &GetterChain{
+		layers: layers,
+	}
+}
+
+// WithSetPolicy sets policy of writes (SetFirst by default).
+func (that *GetterChain) WithSetPolicy(policy SetPolicy) *GetterChain {
+	that.policy = policy
+	return that
+}
+
+func (that *GetterChain) GetProperty(
+	ctx context.Context,
+	name string,
+) (interface{}, error) {
+	value, _, err := that.resolve(ctx, name)
+	return value, err
+}
+
+// Which returns index of the layer, that answers the name.
+func (that *GetterChain) Which(
+	ctx context.Context,
+	name string,
+) (int, error) {
+	_, index, err := that.resolve(ctx, name)
+	return index, err
+}
+
+func (that *GetterChain) SetProperty(
+	ctx context.Context,
+	name string,
+	value interface{},
+) error {
+	switch that.policy {
+	case SetNone:
+		return ErrReadOnly
+	case SetAll:
+		found := false
+		for _, layer := range that.layers {
+			if setter, ok := layer.(Setter); ok {
+				found = true
+				if err := setter.SetProperty(ctx, name, value); err != nil {
+					return err
+				}
+			}
+		}
+		if !found {
+			return ErrReadOnly
+		}
+		return nil
+	case SetOwner:
+		_, index, err := that.resolve(ctx, name)
+		if err != nil && !errors.Is(err, types.ErrNoMatch) {
+			return err
+		}
+		if index >= 0 {
+			if setter, ok := that.layers[index].(Setter); ok {
+				return setter.SetProperty(ctx, name, value)
+			}
+		}
+	}
+
+	setter := that.setter()
+	if setter == nil {
+		return ErrReadOnly
+	}
+
+	return setter.SetProperty(ctx, name, value)
+}
+
+func (that *GetterChain) setter() Setter {
+	if that.policy == SetLast {
+		for i := len(that.layers) - 1; i >= 0; i-- {
+			if setter, ok := that.layers[i].(Setter); ok {
+				return setter
+			}
+		}
+		return nil
+	}
+
+	for _, layer := range that.layers {
+		if setter, ok := layer.(Setter); ok {
+			return setter
+		}
+	}
+	return nil
+}
+
+func (that *GetterChain) resolve(
+	ctx context.Context,
+	name string,
+) (interface{}, int, error) {
+	for i, layer := range that.layers {
+		value, err := layer.GetProperty(ctx, name)
+		if err == nil {
+			return value, i, nil
+		}
+		if !errors.Is(err, types.ErrNoMatch) {
+			return nil, i, err
+		}
+	}
+
+	return nil, -1, types.ErrNoMatch
+}
+
+// NewContextGetter is constructor for build Getter of context values (see context.WithValue).
+// Names are used as keys of context.
+func NewContextGetter() Getter {
+	return ctxGetter
+}
+
+var (
+	ErrReadOnly = errors.New("read only")
+)
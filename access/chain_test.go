@@ -0,0 +1,80 @@
+package access
+
+import (
+	"context"
+	"testing"
+
+	"github.com/adverax/metacrm.kernel/containers/maps"
+	"github.com/adverax/metacrm.kernel/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChain(t *testing.T) {
+	request := maps.Map{"lang": "de"}
+	tenant := maps.Map{"lang": "fr", "theme": "dark"}
+	app := maps.Map{"theme": "light", "timeout": 10}
+
+	ctx := context.WithValue(context.Background(), "user", "admin")
+	chain := Chain(request, tenant, app, NewContextGetter())
+
+	tests := []struct {
+		name  string
+		value interface{}
+		layer int
+	}{
+		{name: "lang", value: "de", layer: 0},
+		{name: "theme", value: "dark", layer: 1},
+		{name: "timeout", value: 10, layer: 2},
+		{name: "user", value: "admin", layer: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := chain.GetProperty(ctx, tt.name)
+			require.NoError(t, err)
+			assert.Equal(t, tt.value, value)
+
+			layer, err := chain.Which(ctx, tt.name)
+			require.NoError(t, err)
+			assert.Equal(t, tt.layer, layer)
+		})
+	}
+
+	_, err := chain.GetProperty(ctx, "unknown")
+	assert.ErrorIs(t, err, types.ErrNoMatch)
+	layer, _ := chain.Which(ctx, "unknown")
+	assert.Equal(t, -1, layer)
+}
+
+func TestChain_SetPolicy(t *testing.T) {
+	ctx := context.Background()
+	tests := map[SetPolicy][]maps.Map{
+		SetFirst: {{"a": 1, "b": 9}, {"a": 2, "b": 3}},
+		SetLast:  {{"a": 1}, {"a": 2, "b": 9}},
+		SetOwner: {{"a": 1}, {"a": 2, "b": 9}},
+		SetAll:   {{"a": 1, "b": 9}, {"a": 2, "b": 9}},
+	}
+
+	for policy, expected := range tests {
+		first := maps.Map{"a": 1}
+		second := maps.Map{"a": 2, "b": 3}
+		chain := Chain(first, second).WithSetPolicy(policy)
+		require.NoError(t, chain.SetProperty(ctx, "b", 9))
+		assert.Equal(t, expected, []maps.Map{first, second}, "policy %d", policy)
+	}
+
+	err := Chain(maps.Map{}).WithSetPolicy(SetNone).SetProperty(ctx, "a", 1)
+	assert.ErrorIs(t, err, ErrReadOnly)
+	err = Chain(NewContextGetter()).SetProperty(ctx, "a", 1)
+	assert.ErrorIs(t, err, ErrReadOnly)
+}
+
+func TestChain_PlainDollarKey(t *testing.T) {
+	ctx := context.Background()
+	chain := Chain(maps.Map{"theme": "dark"}, maps.Map{"$merge": "append"})
+
+	value, err := chain.GetProperty(ctx, "$merge")
+	require.NoError(t, err)
+	assert.Equal(t, "append", value)
+}
@@ -1,6 +1,9 @@
 package fileFetcher
 
-import "errors"
+import (
+	"errors"
+	"os"
+)
 
 type Builder struct {
 	fetcher *Fetcher
@@ -8,10 +11,13 @@ type Builder struct {
 
 func NewBuilder() *Builder {
 	return &Builder{
-		fetcher: &Fetcher{},
+		fetcher: &Fetcher{
+			perm: 0644,
+		},
 	}
 }
 
+// WithFilename sets name of file. Saves create lock file "<filename>.lock" next to the file (see Fetcher.Save).
 func (that *Builder) WithFilename(filename string) *Builder {
 	that.fetcher.filename = filename
 	return that
@@ -22,6 +28,18 @@ func (that *Builder) WithMustExists(mustExists bool) *Builder {
 	return that
 }
 
+// WithBackup enables keeping of previous version of file as "<filename>.bak" on save.
+func (that *Builder) WithBackup(backup bool) *Builder {
+	that.fetcher.backup = backup
+	return that
+}
+
+// WithPerm sets permissions of new file (default 0644). Permissions of existing file are preserved.
+func (that *Builder) WithPerm(perm os.FileMode) *Builder {
+	that.fetcher.perm = perm
+	return that
+}
+
 func (that *Builder) Build() (*Fetcher, error) {
 	if err := that.checkRequiredFields(); err != nil {
 		return nil, err
@@ -39,4 +57,5 @@ func (that *Builder) checkRequiredFields() error {
 
 var (
 	ErrFilenameRequired = errors.New("filename is required")
+	ErrTooManyLinks     = errors.New("too many links")
 )
@@ -3,11 +3,16 @@ package fileFetcher
 import (
 	"io"
 	"os"
+	"path/filepath"
+	"sync"
 )
 
 type Fetcher struct {
+	mx         sync.Mutex
 	filename   string
 	mustExists bool
+	backup     bool
+	perm       os.FileMode
 }
 
 func (that *Fetcher) Fetch() ([]byte, error) {
@@ -23,6 +28,166 @@ func (that *Fetcher) Fetch() ([]byte, error) {
 	return io.ReadAll(file)
 }
 
+func (that *Fetcher) Filename() string {
+	return that.filename
+}
+
+// Save replaces file atomically: data is written into temporary file, that is renamed over the file.
+// Permissions of existing file are preserved. Symbolic link is kept, its target is replaced.
+// Concurrent saves (including other processes) are serialized by advisory lock of file "<filename>.lock".
+// The lock file is kept after save: removing it would let another process lock the new file
+// while the old one is still locked.
 func (that *Fetcher) Save(data []byte) error {
-	return os.WriteFile(that.filename, data, 0644)
+	filename, unlock, err := that.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	return that.save(filename, data)
+}
+
+// Update reads file, changes its content by action and saves the result under the lock of Save,
+// so concurrent updates don't lose changes. File is not saved, if action fails.
+func (that *Fetcher) Update(action func(data []byte) ([]byte, error)) error {
+	filename, unlock, err := that.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	data, err := os.ReadFile(filename)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	data, err = action(data)
+	if err != nil {
+		return err
+	}
+
+	return that.save(filename, data)
+}
+
+// lock resolves symbolic links of the file and locks the target.
+func (that *Fetcher) lock() (filename string, unlock func(), err error) {
+	that.mx.Lock()
+
+	filename, err = filepath.EvalSymlinks(that.filename)
+	if os.IsNotExist(err) {
+		// Target of dangling link is created by save
+		filename, err = readLinks(that.filename)
+	}
+	if err != nil {
+		that.mx.Unlock()
+		return "", nil, err
+	}
+
+	unlockFile, err := lockFile(filename + ".lock")
+	if err != nil {
+		that.mx.Unlock()
+		return "", nil, err
+	}
+
+	return filename, func() {
+		unlockFile()
+		that.mx.Unlock()
+	}, nil
 }
+
+// readLinks follows symbolic links of the file, that may not exist yet.
+func readLinks(filename string) (string, error) {
+	for i := 0; i < maxLinks; i++ {
+		info, err := os.Lstat(filename)
+		if os.IsNotExist(err) {
+			return filename, nil
+		}
+		if err != nil {
+			return "", err
+		}
+		if info.Mode()&os.ModeSymlink == 0 {
+			return filename, nil
+		}
+
+		target, err := os.Readlink(filename)
+		if err != nil {
+			return "", err
+		}
+		if !filepath.IsAbs(target) {
+			target = filepath.Join(filepath.Dir(filename), target)
+		}
+		filename = target
+	}
+
+	return "", ErrTooManyLinks
+}
+
+func (that *Fetcher) save(filename string, data []byte) error {
+	perm := that.perm
+	info, err := os.Stat(filename)
+	switch {
+	case err == nil:
+		perm = info.Mode().Perm()
+		if that.backup {
+			if err := rotate(filename, perm); err != nil {
+				return err
+			}
+		}
+	case !os.IsNotExist(err):
+		return err
+	}
+
+	return writeFile(filename, data, perm)
+}
+
+// rotate copies current file into "<filename>.bak".
+func rotate(filename string, perm os.FileMode) error {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+
+	return writeFile(filename+".bak", data, perm)
+}
+
+// writeFile writes data into temporary file in the same directory and renames it over the filename.
+func writeFile(filename string, data []byte, perm os.FileMode) error {
+	dir, base := filepath.Split(filename)
+	if dir == "" {
+		dir = "."
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+base+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+
+	if err := tmp.Chmod(perm); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmp.Name(), filename); err != nil {
+		return err
+	}
+
+	// Rename is durable only after sync of the directory
+	return syncDir(dir)
+}
+
+const maxLinks = 255
@@ -0,0 +1,185 @@
+package fileFetcher
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetcher_Save(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "config.json")
+
+	fetcher, err := NewBuilder().
+		WithFilename(filename).
+		WithBackup(true).
+		WithPerm(0600).
+		Build()
+	require.NoError(t, err)
+
+	data, err := fetcher.Fetch()
+	require.NoError(t, err)
+	assert.Nil(t, data)
+
+	require.NoError(t, fetcher.Save([]byte("v1")))
+	info, err := os.Stat(filename)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	assert.NoFileExists(t, filename+".bak")
+
+	require.NoError(t, os.Chmod(filename, 0640))
+	require.NoError(t, fetcher.Save([]byte("v2")))
+
+	data, err = fetcher.Fetch()
+	require.NoError(t, err)
+	assert.Equal(t, "v2", string(data))
+
+	backup, err := os.ReadFile(filename + ".bak")
+	require.NoError(t, err)
+	assert.Equal(t, "v1", string(backup))
+
+	info, err = os.Stat(filename)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0640), info.Mode().Perm())
+
+	entries, err := os.ReadDir(filepath.Dir(filename))
+	require.NoError(t, err)
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	assert.ElementsMatch(t, []string{"config.json", "config.json.bak", "config.json.lock"}, names)
+}
+
+func TestFetcher_ConcurrentSave(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "config.json")
+	payloads := []string{"aaaaaaaa", "bbbbbbbb", "cccccccc", "dddddddd"}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, len(payloads))
+	for _, payload := range payloads {
+		wg.Add(1)
+		go func(payload string) {
+			defer wg.Done()
+			errs <- func() error {
+				fetcher, err := NewBuilder().WithFilename(filename).Build()
+				if err != nil {
+					return err
+				}
+				for i := 0; i < 20; i++ {
+					if err := fetcher.Save([]byte(payload)); err != nil {
+						return err
+					}
+				}
+				return nil
+			}()
+		}(payload)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	data, err := os.ReadFile(filename)
+	require.NoError(t, err)
+	assert.Contains(t, payloads, string(data))
+}
+
+func TestFetcher_SaveSymlink(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "shared", "config.json")
+	require.NoError(t, os.Mkdir(filepath.Dir(target), 0755))
+	require.NoError(t, os.WriteFile(target, []byte("v1"), 0600))
+	link := filepath.Join(dir, "config.json")
+	require.NoError(t, os.Symlink(target, link))
+
+	fetcher, err := NewBuilder().
+		WithFilename(link).
+		WithBackup(true).
+		Build()
+	require.NoError(t, err)
+	require.NoError(t, fetcher.Save([]byte("v2")))
+
+	info, err := os.Lstat(link)
+	require.NoError(t, err)
+	assert.Equal(t, os.ModeSymlink, info.Mode()&os.ModeSymlink)
+
+	data, err := os.ReadFile(target)
+	require.NoError(t, err)
+	assert.Equal(t, "v2", string(data))
+
+	info, err = os.Stat(target)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	assert.FileExists(t, target+".bak")
+	assert.FileExists(t, target+".lock")
+}
+
+func TestFetcher_SaveDanglingSymlink(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "shared"), 0755))
+	link := filepath.Join(dir, "config.json")
+	require.NoError(t, os.Symlink(filepath.Join("shared", "config.json"), link))
+
+	fetcher, err := NewBuilder().WithFilename(link).Build()
+	require.NoError(t, err)
+	require.NoError(t, fetcher.Save([]byte("v1")))
+
+	info, err := os.Lstat(link)
+	require.NoError(t, err)
+	assert.Equal(t, os.ModeSymlink, info.Mode()&os.ModeSymlink)
+
+	data, err := os.ReadFile(filepath.Join(dir, "shared", "config.json"))
+	require.NoError(t, err)
+	assert.Equal(t, "v1", string(data))
+}
+
+func TestFetcher_ConcurrentUpdate(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file locks are not supported")
+	}
+
+	filename := filepath.Join(t.TempDir(), "counter")
+	const workers, updates = 4, 20
+
+	var wg sync.WaitGroup
+	errs := make(chan error, workers)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- func() error {
+				// Separate fetchers are synchronized by the file lock only
+				fetcher, err := NewBuilder().WithFilename(filename).Build()
+				if err != nil {
+					return err
+				}
+				for j := 0; j < updates; j++ {
+					err := fetcher.Update(func(data []byte) ([]byte, error) {
+						counter, _ := strconv.Atoi(string(data))
+						return []byte(strconv.Itoa(counter + 1)), nil
+					})
+					if err != nil {
+						return err
+					}
+				}
+				return nil
+			}()
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	data, err := os.ReadFile(filename)
+	require.NoError(t, err)
+	assert.Equal(t, strconv.Itoa(workers*updates), string(data))
+}
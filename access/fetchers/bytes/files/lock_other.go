@@ -0,0 +1,13 @@
+//go:build !unix
+
+package fileFetcher
+
+// lockFile is not supported on this platform, so saves are serialized only within the process.
+func lockFile(filename string) (unlock func(), err error) {
+	return func() {}, nil
+}
+
+// syncDir is not supported on this platform (directories can't be opened for sync).
+func syncDir(dir string) error {
+	return nil
+}
@@ -0,0 +1,37 @@
+//go:build unix
+
+package fileFetcher
+
+import (
+	"os"
+	"syscall"
+)
+
+// lockFile acquires exclusive advisory lock of the file.
+func lockFile(filename string) (unlock func(), err error) {
+	file, err := os.OpenFile(filename, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := syscall.Flock(int(file.Fd()), syscall.LOCK_EX); err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+
+	return func() {
+		_ = syscall.Flock(int(file.Fd()), syscall.LOCK_UN)
+		_ = file.Close()
+	}, nil
+}
+
+// syncDir flushes entries of the directory (for example after rename) to the disk.
+func syncDir(dir string) error {
+	file, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return file.Sync()
+}
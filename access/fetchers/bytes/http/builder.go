@@ -0,0 +1,81 @@
+package httpFetcher
+
+import (
+	"errors"
+	"net/http"
+	"time"
+)
+
+type Builder struct {
+	fetcher *Fetcher
+}
+
+func NewBuilder() *Builder {
+	return &Builder{
+		fetcher: &Fetcher{
+			client:     http.DefaultClient,
+			timeout:    10 * time.Second,
+			retryDelay: time.Second,
+			headers:    make(http.Header),
+		},
+	}
+}
+
+func (that *Builder) WithUrl(url string) *Builder {
+	that.fetcher.url = url
+	return that
+}
+
+func (that *Builder) WithClient(client *http.Client) *Builder {
+	that.fetcher.client = client
+	return that
+}
+
+// WithTimeout sets timeout of the single attempt.
+func (that *Builder) WithTimeout(timeout time.Duration) *Builder {
+	that.fetcher.timeout = timeout
+	return that
+}
+
+// WithRetries sets count of retries after failed attempt.
+// Network errors and server errors (5xx) are retried.
+func (that *Builder) WithRetries(retries int, delay time.Duration) *Builder {
+	that.fetcher.retries = retries
+	that.fetcher.retryDelay = delay
+	return that
+}
+
+// WithCacheFile sets file, that keeps the last successful response.
+// The file is used, when server is unavailable.
+func (that *Builder) WithCacheFile(filename string) *Builder {
+	that.fetcher.cacheFile = filename
+	return that
+}
+
+func (that *Builder) WithHeader(key, value string) *Builder {
+	that.fetcher.headers.Add(key, value)
+	return that
+}
+
+func (that *Builder) Build() (*Fetcher, error) {
+	if err := that.checkRequiredFields(); err != nil {
+		return nil, err
+	}
+
+	return that.fetcher, nil
+}
+
+func (that *Builder) checkRequiredFields() error {
+	if that.fetcher.url == "" {
+		return ErrUrlRequired
+	}
+	if that.fetcher.client == nil {
+		return ErrClientRequired
+	}
+	return nil
+}
+
+var (
+	ErrUrlRequired    = errors.New("url is required")
+	ErrClientRequired = errors.New("client is required")
+)
@@ -0,0 +1,221 @@
+package httpFetcher
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Fetcher gets content by url. Unchanged content is detected by ETag
+// (request with If-None-Match returns 304 and cached content is reused).
+type Fetcher struct {
+	mx          sync.Mutex
+	url         string
+	client      *http.Client
+	headers     http.Header
+	timeout     time.Duration
+	retries     int
+	retryDelay  time.Duration
+	cacheFile   string
+	etag        string
+	contentType string
+	data        []byte
+	stale       error
+}
+
+func (that *Fetcher) Fetch() ([]byte, error) {
+	return that.FetchContext(context.Background())
+}
+
+// FetchContext gets content. Network errors, server errors (5xx) and timeouts are retried.
+// When server is unavailable, content of the cache file is returned (see Stale).
+// Other errors (for example 401, 404) are returned as is, because cached content is not valid for them.
+func (that *Fetcher) FetchContext(ctx context.Context) ([]byte, error) {
+	var err error
+	var retry bool
+	for attempt := 0; attempt <= that.retries; attempt++ {
+		if attempt != 0 {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(that.retryDelay):
+			}
+		}
+
+		var data []byte
+		data, retry, err = that.attempt(ctx)
+		if err == nil {
+			return data, nil
+		}
+		if !retry || ctx.Err() != nil {
+			break
+		}
+	}
+
+	if !retry || ctx.Err() != nil {
+		return nil, err
+	}
+
+	that.mx.Lock()
+	defer that.mx.Unlock()
+
+	data, e := that.loadCache()
+	if e != nil {
+		return nil, err
+	}
+	that.stale = err
+	return data, nil
+}
+
+// Stale returns error of the server, if the last fetch returned cached content, and nil otherwise.
+func (that *Fetcher) Stale() error {
+	that.mx.Lock()
+	defer that.mx.Unlock()
+
+	return that.stale
+}
+
+func (that *Fetcher) attempt(ctx context.Context) (data []byte, retry bool, err error) {
+	that.mx.Lock()
+	defer that.mx.Unlock()
+
+	data, retry, err = that.fetch(ctx)
+	if err == nil {
+		that.stale = nil
+	}
+	return data, retry, err
+}
+
+// Url returns url of content.
+func (that *Fetcher) Url() string {
+	return that.url
+}
+
+// ContentType returns content type of the last response.
+func (that *Fetcher) ContentType() string {
+	that.mx.Lock()
+	defer that.mx.Unlock()
+
+	return that.contentType
+}
+
+func (that *Fetcher) fetch(ctx context.Context) (data []byte, retry bool, err error) {
+	if that.timeout != 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, that.timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, that.url, nil)
+	if err != nil {
+		return nil, false, err
+	}
+
+	for key, values := range that.headers {
+		req.Header[key] = values
+	}
+	if that.etag != "" && that.data != nil {
+		req.Header.Set("If-None-Match", that.etag)
+	}
+
+	resp, err := that.client.Do(req)
+	if err != nil {
+		return nil, true, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && that.data != nil:
+		return that.data, false, nil
+	case resp.StatusCode == http.StatusOK:
+		data, err = io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, true, err
+		}
+		that.etag = resp.Header.Get("ETag")
+		that.contentType = resp.Header.Get("Content-Type")
+		that.data = data
+		// Cache is optional: content is valid, even if it can't be saved
+		_ = that.saveCache(data)
+		return data, false, nil
+	}
+
+	err = fmt.Errorf("%w: %s %s", ErrUnexpectedStatus, resp.Status, that.url)
+	return nil, resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests, err
+}
+
+// cacheMeta is metadata of cached content. It is kept in file with suffix ".meta" next to the cache file.
+type cacheMeta struct {
+	ETag        string `json:"etag,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+}
+
+// loadCache restores content, ETag and content type of the last successful response.
+func (that *Fetcher) loadCache() ([]byte, error) {
+	if that.cacheFile == "" {
+		return nil, os.ErrNotExist
+	}
+
+	data, err := os.ReadFile(that.cacheFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var meta cacheMeta
+	if raw, err := os.ReadFile(that.cacheFile + ".meta"); err == nil {
+		_ = json.Unmarshal(raw, &meta)
+	}
+
+	that.data = data
+	that.etag = meta.ETag
+	that.contentType = meta.ContentType
+	return data, nil
+}
+
+func (that *Fetcher) saveCache(data []byte) error {
+	if that.cacheFile == "" {
+		return nil
+	}
+
+	meta, err := json.Marshal(cacheMeta{ETag: that.etag, ContentType: that.contentType})
+	if err != nil {
+		return err
+	}
+
+	// Content is written first: stale ETag only causes full request, but new ETag with stale content
+	// would cause reusing of stale content.
+	if err := writeFile(that.cacheFile, data); err != nil {
+		return err
+	}
+
+	return writeFile(that.cacheFile+".meta", meta)
+}
+
+func writeFile(filename string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(filename), filepath.Base(filename)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), filename)
+}
+
+var (
+	ErrUnexpectedStatus = errors.New("unexpected status")
+)
@@ -0,0 +1,235 @@
+package httpFetcher
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetcher_ETag(t *testing.T) {
+	var requests, notModified int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			atomic.AddInt32(&notModified, 1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"name":"My App"}`))
+	}))
+	defer server.Close()
+
+	cacheFile := filepath.Join(t.TempDir(), "config.cache")
+	fetcher, err := NewBuilder().
+		WithUrl(server.URL).
+		WithCacheFile(cacheFile).
+		Build()
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		data, err := fetcher.Fetch()
+		require.NoError(t, err)
+		assert.Equal(t, `{"name":"My App"}`, string(data))
+	}
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&notModified))
+	assert.Equal(t, "application/json", fetcher.ContentType())
+
+	cached, err := os.ReadFile(cacheFile)
+	require.NoError(t, err)
+	assert.Equal(t, `{"name":"My App"}`, string(cached))
+}
+
+func TestFetcher_Retries(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`ok`))
+	}))
+	defer server.Close()
+
+	fetcher, err := NewBuilder().
+		WithUrl(server.URL).
+		WithRetries(2, time.Millisecond).
+		Build()
+	require.NoError(t, err)
+
+	data, err := fetcher.Fetch()
+	require.NoError(t, err)
+	assert.Equal(t, "ok", string(data))
+	assert.Equal(t, int32(3), atomic.LoadInt32(&requests))
+}
+
+func TestFetcher_Fallback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	cacheFile := filepath.Join(t.TempDir(), "config.cache")
+	fetcher, err := NewBuilder().
+		WithUrl(server.URL).
+		WithTimeout(10*time.Millisecond).
+		WithRetries(1, time.Millisecond).
+		Build()
+	require.NoError(t, err)
+
+	_, err = fetcher.Fetch()
+	assert.Error(t, err)
+
+	require.NoError(t, os.WriteFile(cacheFile, []byte(`cached`), 0644))
+	fetcher.cacheFile = cacheFile
+	data, err := fetcher.Fetch()
+	require.NoError(t, err)
+	assert.Equal(t, "cached", string(data))
+
+	notFound := httptest.NewServer(http.NotFoundHandler())
+	defer notFound.Close()
+	fetcher.url = notFound.URL
+	fetcher.cacheFile = ""
+	_, err = fetcher.Fetch()
+	assert.ErrorIs(t, err, ErrUnexpectedStatus)
+}
+
+func TestFetcher_CacheMeta(t *testing.T) {
+	var available int32 = 1
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.LoadInt32(&available) == 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Content-Type", "application/yaml")
+		_, _ = w.Write([]byte(`name: My App`))
+	}))
+	defer server.Close()
+
+	cacheFile := filepath.Join(t.TempDir(), "config.cache")
+	fetcher, err := NewBuilder().
+		WithUrl(server.URL).
+		WithCacheFile(cacheFile).
+		Build()
+	require.NoError(t, err)
+
+	_, err = fetcher.Fetch()
+	require.NoError(t, err)
+
+	// New fetcher restores content type and ETag from the cache
+	atomic.StoreInt32(&available, 0)
+	fetcher, err = NewBuilder().
+		WithUrl(server.URL).
+		WithCacheFile(cacheFile).
+		Build()
+	require.NoError(t, err)
+
+	data, err := fetcher.Fetch()
+	require.NoError(t, err)
+	assert.Equal(t, "name: My App", string(data))
+	assert.Equal(t, "application/yaml", fetcher.ContentType())
+
+	atomic.StoreInt32(&available, 1)
+	data, err = fetcher.Fetch()
+	require.NoError(t, err)
+	assert.Equal(t, "name: My App", string(data))
+}
+
+func TestFetcher_CacheFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`fresh`))
+	}))
+	defer server.Close()
+
+	fetcher, err := NewBuilder().
+		WithUrl(server.URL).
+		WithCacheFile(filepath.Join(t.TempDir(), "missing", "config.cache")).
+		Build()
+	require.NoError(t, err)
+
+	data, err := fetcher.Fetch()
+	require.NoError(t, err)
+	assert.Equal(t, "fresh", string(data))
+}
+
+func TestFetcher_Stale(t *testing.T) {
+	var status int32 = http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if code := int(atomic.LoadInt32(&status)); code != http.StatusOK {
+			w.WriteHeader(code)
+			return
+		}
+		_, _ = w.Write([]byte(`fresh`))
+	}))
+	defer server.Close()
+
+	fetcher, err := NewBuilder().
+		WithUrl(server.URL).
+		WithCacheFile(filepath.Join(t.TempDir(), "config.cache")).
+		Build()
+	require.NoError(t, err)
+
+	_, err = fetcher.Fetch()
+	require.NoError(t, err)
+	assert.NoError(t, fetcher.Stale())
+
+	// server errors are served from the cache
+	atomic.StoreInt32(&status, http.StatusBadGateway)
+	data, err := fetcher.Fetch()
+	require.NoError(t, err)
+	assert.Equal(t, "fresh", string(data))
+	assert.ErrorIs(t, fetcher.Stale(), ErrUnexpectedStatus)
+
+	// client errors are not hidden by the cache
+	atomic.StoreInt32(&status, http.StatusForbidden)
+	_, err = fetcher.Fetch()
+	assert.ErrorIs(t, err, ErrUnexpectedStatus)
+
+	atomic.StoreInt32(&status, http.StatusOK)
+	_, err = fetcher.Fetch()
+	require.NoError(t, err)
+	assert.NoError(t, fetcher.Stale())
+}
+
+func TestFetcher_ContextCanceled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	fetcher, err := NewBuilder().
+		WithUrl(server.URL).
+		WithRetries(10, time.Hour).
+		Build()
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		_, err := fetcher.FetchContext(ctx)
+		done <- err
+	}()
+
+	// lock is not held while waiting for retry
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, "", fetcher.ContentType())
+
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+}
@@ -13,3 +13,9 @@ func New(data []byte) *Fetcher {
 func (that *Fetcher) Fetch() ([]byte, error) {
 	return that.data, nil
 }
+
+// Save replaces data of the fetcher.
+func (that *Fetcher) Save(data []byte) error {
+	that.data = data
+	return nil
+}
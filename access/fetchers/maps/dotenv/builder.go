@@ -0,0 +1,59 @@
+package dotenvFetcher
+
+import (
+	"errors"
+
+	envFetcher "github.com/adverax/metacrm.kernel/access/fetchers/maps/env"
+)
+
+type Builder struct {
+	engine *Engine
+}
+
+func NewBuilder() *Builder {
+	return &Builder{
+		engine: &Engine{},
+	}
+}
+
+func (that *Builder) WithFetcher(fetcher Fetcher) *Builder {
+	that.engine.fetcher = fetcher
+	return that
+}
+
+// WithGuard filters variables and strips their names (for example by prefix).
+func (that *Builder) WithGuard(guard envFetcher.Guard) *Builder {
+	that.engine.guard = guard
+	return that
+}
+
+// WithDelimiter enables building of nested map by splitting names with delimiter.
+func (that *Builder) WithDelimiter(delimiter string) *Builder {
+	that.engine.delimiter = delimiter
+	return that
+}
+
+// WithLowerCase enables converting names to lower case.
+func (that *Builder) WithLowerCase(lowerCase bool) *Builder {
+	that.engine.lowerCase = lowerCase
+	return that
+}
+
+func (that *Builder) Build() (*Engine, error) {
+	if err := that.checkRequiredFields(); err != nil {
+		return nil, err
+	}
+
+	return that.engine, nil
+}
+
+func (that *Builder) checkRequiredFields() error {
+	if that.engine.fetcher == nil {
+		return ErrFetcherRequired
+	}
+	return nil
+}
+
+var (
+	ErrFetcherRequired = errors.New("fetcher is required")
+)
@@ -0,0 +1,73 @@
+package dotenvFetcher
+
+import (
+	"strings"
+
+	envFetcher "github.com/adverax/metacrm.kernel/access/fetchers/maps/env"
+)
+
+type Fetcher interface {
+	Fetch() ([]byte, error)
+}
+
+// Engine reads variables from dotenv file.
+// By default variables are returned as flat map with original names.
+type Engine struct {
+	fetcher   Fetcher
+	guard     envFetcher.Guard
+	delimiter string
+	lowerCase bool
+}
+
+func New(fetcher Fetcher) *Engine {
+	return &Engine{
+		fetcher: fetcher,
+	}
+}
+
+func (that *Engine) Fetch() (map[string]interface{}, error) {
+	source, err := that.fetcher.Fetch()
+	if err != nil {
+		return nil, err
+	}
+
+	pairs, err := Parse(source)
+	if err != nil {
+		return nil, err
+	}
+
+	if that.delimiter == "" {
+		data := make(map[string]interface{}, len(pairs))
+		for _, pair := range pairs {
+			if key, ok := that.keyOf(pair.Key); ok {
+				data[key] = pair.Value
+			}
+		}
+		return data, nil
+	}
+
+	accumulator := envFetcher.NewKeyPathAccumulator(that.delimiter)
+	for _, pair := range pairs {
+		if key, ok := that.keyOf(pair.Key); ok {
+			accumulator.Add(key, pair.Value)
+		}
+	}
+	return accumulator.Result(), nil
+}
+
+func (that *Engine) keyOf(name string) (string, bool) {
+	key := name
+	if that.guard != nil {
+		var ok bool
+		key, ok = that.guard.IsSatisfied(name)
+		if !ok {
+			return "", false
+		}
+	}
+
+	if that.lowerCase {
+		key = strings.ToLower(key)
+	}
+
+	return key, true
+}
@@ -0,0 +1,199 @@
+package dotenvFetcher
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Pair is variable, defined in the dotenv file.
+type Pair struct {
+	Key   string
+	Value string
+}
+
+// Parse parses dotenv document. Supported syntax:
+//   - KEY=value (value is trimmed, inline comments are started by " #");
+//   - export KEY=value;
+//   - KEY="value" (escape sequences \n, \r, \t, \", \\ and \$ are expanded, line breaks are allowed);
+//   - KEY='value' (value is taken literally, line breaks are allowed);
+//   - # comment.
+func Parse(data []byte) ([]Pair, error) {
+	p := &parser{src: string(data), line: 1}
+	return p.parse()
+}
+
+type parser struct {
+	src  string
+	pos  int
+	line int
+}
+
+func (that *parser) parse() ([]Pair, error) {
+	var pairs []Pair
+	for {
+		that.skipBlank()
+		if that.eof() {
+			return pairs, nil
+		}
+
+		if that.peek() == '#' {
+			that.skipLine()
+			continue
+		}
+
+		pair, err := that.parsePair()
+		if err != nil {
+			return nil, err
+		}
+		pairs = append(pairs, pair)
+	}
+}
+
+func (that *parser) parsePair() (Pair, error) {
+	if strings.HasPrefix(that.src[that.pos:], "export ") {
+		that.pos += len("export ")
+		that.skipSpaces()
+	}
+
+	start := that.pos
+	for !that.eof() && isKeyChar(that.peek()) {
+		that.pos++
+	}
+	key := that.src[start:that.pos]
+	if key == "" {
+		return Pair{}, that.errorf("expected variable name")
+	}
+
+	that.skipSpaces()
+	if that.eof() || that.peek() != '=' {
+		return Pair{}, that.errorf("expected '=' after %q", key)
+	}
+	that.pos++
+	that.skipSpaces()
+
+	var value string
+	var err error
+	switch that.peek() {
+	case '"':
+		value, err = that.parseQuoted('"')
+	case '\'':
+		value, err = that.parseQuoted('\'')
+	default:
+		value = that.parseUnquoted()
+	}
+	if err != nil {
+		return Pair{}, err
+	}
+
+	that.skipSpaces()
+	if !that.eof() && that.peek() == '#' {
+		that.skipLine()
+	}
+	if !that.eof() && that.peek() != '\n' && that.peek() != '\r' {
+		return Pair{}, that.errorf("unexpected characters after value of %q", key)
+	}
+
+	return Pair{Key: key, Value: value}, nil
+}
+
+func (that *parser) parseUnquoted() string {
+	start := that.pos
+	for !that.eof() && that.peek() != '\n' {
+		if that.peek() == '#' && that.pos > start && isSpace(that.src[that.pos-1]) {
+			break
+		}
+		that.pos++
+	}
+	return strings.TrimSpace(that.src[start:that.pos])
+}
+
+func (that *parser) parseQuoted(quote byte) (string, error) {
+	that.pos++
+	var buf strings.Builder
+	for {
+		if that.eof() {
+			return "", that.errorf("unterminated quoted value")
+		}
+
+		ch := that.peek()
+		that.pos++
+		switch {
+		case ch == quote:
+			return buf.String(), nil
+		case ch == '\\' && quote == '"' && !that.eof():
+			next := that.peek()
+			that.pos++
+			switch next {
+			case 'n':
+				buf.WriteByte('\n')
+			case 'r':
+				buf.WriteByte('\r')
+			case 't':
+				buf.WriteByte('\t')
+			case '"', '\\', '$':
+				buf.WriteByte(next)
+			default:
+				buf.WriteByte('\\')
+				buf.WriteByte(next)
+			}
+		default:
+			if ch == '\n' {
+				that.line++
+			}
+			buf.WriteByte(ch)
+		}
+	}
+}
+
+func (that *parser) skipBlank() {
+	for !that.eof() {
+		switch that.peek() {
+		case '\n':
+			that.line++
+			that.pos++
+		case ' ', '\t', '\r':
+			that.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (that *parser) skipSpaces() {
+	for !that.eof() && isSpace(that.peek()) {
+		that.pos++
+	}
+}
+
+func (that *parser) skipLine() {
+	for !that.eof() && that.peek() != '\n' {
+		that.pos++
+	}
+}
+
+func (that *parser) eof() bool {
+	return that.pos >= len(that.src)
+}
+
+// peek returns current character or 0 at the end of document.
+func (that *parser) peek() byte {
+	if that.eof() {
+		return 0
+	}
+	return that.src[that.pos]
+}
+
+func (that *parser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("dotenv: line %d: %s", that.line, fmt.Sprintf(format, args...))
+}
+
+func isSpace(ch byte) bool {
+	return ch == ' ' || ch == '\t'
+}
+
+func isKeyChar(ch byte) bool {
+	return ch == '_' || ch == '.' || ch == '-' ||
+		(ch >= '0' && ch <= '9') ||
+		(ch >= 'a' && ch <= 'z') ||
+		(ch >= 'A' && ch <= 'Z')
+}
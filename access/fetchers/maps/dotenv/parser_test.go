@@ -0,0 +1,87 @@
+package dotenvFetcher
+
+import (
+	"testing"
+
+	memoryFetcher "github.com/adverax/metacrm.kernel/access/fetchers/bytes/memory"
+	envFetcher "github.com/adverax/metacrm.kernel/access/fetchers/maps/env"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	src := `# Database settings
+DB_HOST=localhost
+export DB_PORT = 5432 # inline comment
+DB_URL=postgres://user@host/db#fragment
+EMPTY=
+DOUBLE="line1\nline2 \"quoted\""
+SINGLE='raw \n value'
+MULTI="first
+second"
+`
+
+	pairs, err := Parse([]byte(src))
+	require.NoError(t, err)
+	assert.Equal(t, []Pair{
+		{Key: "DB_HOST", Value: "localhost"},
+		{Key: "DB_PORT", Value: "5432"},
+		{Key: "DB_URL", Value: "postgres://user@host/db#fragment"},
+		{Key: "EMPTY", Value: ""},
+		{Key: "DOUBLE", Value: "line1\nline2 \"quoted\""},
+		{Key: "SINGLE", Value: `raw \n value`},
+		{Key: "MULTI", Value: "first\nsecond"},
+	}, pairs)
+}
+
+func TestParse_EmptyLastValue(t *testing.T) {
+	for _, src := range []string{"A=1\nEMPTY=", "A=1\nEMPTY=  ", "EMPTY="} {
+		pairs, err := Parse([]byte(src))
+		require.NoError(t, err, src)
+		assert.Equal(t, Pair{Key: "EMPTY", Value: ""}, pairs[len(pairs)-1], src)
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := map[string]string{
+		"missing equal sign":  "KEY value",
+		"unterminated quotes": `KEY="value`,
+		"garbage after quote": `KEY="value" tail`,
+	}
+
+	for name, src := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Parse([]byte(src))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestEngine_Fetch(t *testing.T) {
+	src := []byte("MYAPP_ADDRESS_HOST=google.com\nMYAPP_NAME=\"My App\"\nOTHER=1\n")
+
+	flat, err := New(memoryFetcher.New(src)).Fetch()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"MYAPP_ADDRESS_HOST": "google.com",
+		"MYAPP_NAME":         "My App",
+		"OTHER":              "1",
+	}, flat)
+
+	engine, err := NewBuilder().
+		WithFetcher(memoryFetcher.New(src)).
+		WithGuard(envFetcher.NewPrefixGuard("MYAPP_")).
+		WithDelimiter("_").
+		WithLowerCase(true).
+		Build()
+	require.NoError(t, err)
+
+	nested, err := engine.Fetch()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"address": map[string]interface{}{
+			"host": "google.com",
+		},
+		"name": "My App",
+	}, nested)
+}
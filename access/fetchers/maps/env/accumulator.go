@@ -7,6 +7,7 @@ type KeyPathAccumulator struct {
 	delim string
 }
 
+// NewKeyPathAccumulator creates accumulator of nested map. Empty delimiter means flat map.
 func NewKeyPathAccumulator(delim string) *KeyPathAccumulator {
 	return &KeyPathAccumulator{
 		data:  make(map[string]interface{}),
@@ -15,11 +16,21 @@ func NewKeyPathAccumulator(delim string) *KeyPathAccumulator {
 }
 
 func (that *KeyPathAccumulator) Add(key, value string) {
+	that.Set(key, value)
+}
+
+// Set is like Add, but accepts typed value.
+func (that *KeyPathAccumulator) Set(key string, value interface{}) {
+	if that.delim == "" {
+		that.data[key] = value
+		return
+	}
+
 	keys := strings.Split(key, that.delim)
 	that.add(that.data, keys, value)
 }
 
-func (that *KeyPathAccumulator) add(data map[string]interface{}, keys []string, val string) {
+func (that *KeyPathAccumulator) add(data map[string]interface{}, keys []string, val interface{}) {
 	if len(keys) == 0 {
 		return
 	}
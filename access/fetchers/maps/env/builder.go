@@ -0,0 +1,75 @@
+package envFetcher
+
+import (
+	"errors"
+	"os"
+)
+
+type Builder struct {
+	engine *Engine
+}
+
+func NewBuilder() *Builder {
+	return &Builder{
+		engine: &Engine{
+			environ: os.Environ,
+		},
+	}
+}
+
+// WithGuard filters variables and strips their names (for example by prefix).
+func (that *Builder) WithGuard(guard Guard) *Builder {
+	that.engine.guard = guard
+	return that
+}
+
+// WithDelimiter enables building of nested map by splitting names with delimiter.
+func (that *Builder) WithDelimiter(delimiter string) *Builder {
+	that.engine.delimiter = delimiter
+	return that
+}
+
+// WithLowerCase enables converting names to lower case.
+func (that *Builder) WithLowerCase(lowerCase bool) *Builder {
+	that.engine.lowerCase = lowerCase
+	return that
+}
+
+// WithLists enables converting of numeric keys into list elements:
+// APP_SERVERS_0_HOST becomes servers[0].host (requires delimiter).
+func (that *Builder) WithLists(lists bool) *Builder {
+	that.engine.lists = lists
+	return that
+}
+
+// WithJson enables decoding of values, that look like json objects or arrays.
+func (that *Builder) WithJson(json bool) *Builder {
+	that.engine.json = json
+	return that
+}
+
+// WithEnviron sets source of variables in form "key=value" (os.Environ by default).
+func (that *Builder) WithEnviron(environ func() []string) *Builder {
+	that.engine.environ = environ
+	return that
+}
+
+func (that *Builder) Build() (*Engine, error) {
+	if err := that.checkRequiredFields(); err != nil {
+		return nil, err
+	}
+
+	return that.engine, nil
+}
+
+func (that *Builder) checkRequiredFields() error {
+	if that.engine.lists && that.engine.delimiter == "" {
+		return ErrDelimiterRequired
+	}
+
+	return nil
+}
+
+var (
+	ErrDelimiterRequired = errors.New("delimiter is required")
+)
@@ -1,7 +1,11 @@
 package envFetcher
 
 import (
+	"encoding/json"
 	"os"
+	"reflect"
+	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -14,36 +18,137 @@ type Accumulator interface {
 	Result() map[string]interface{}
 }
 
+// Engine reads environment variables.
+// Engine, created by Builder, accumulates variables into the new map on each fetch.
 type Engine struct {
 	guard       Guard
 	accumulator Accumulator
+	environ     func() []string
+	delimiter   string
+	lowerCase   bool
+	lists       bool
+	json        bool
 }
 
 func New(guard Guard, accumulator Accumulator) *Engine {
 	return &Engine{
 		guard:       guard,
 		accumulator: accumulator,
+		environ:     os.Environ,
 	}
 }
 
 func (that *Engine) Fetch() (map[string]interface{}, error) {
-	return that.fetch(os.Environ())
+	return that.fetch(that.environ())
 }
 
 func (that *Engine) fetch(es []string) (map[string]interface{}, error) {
+	accumulator := that.accumulator
+	if accumulator == nil {
+		accumulator = NewKeyPathAccumulator(that.delimiter)
+	}
+
+	// Json objects are kept as is, even if they have numeric keys
+	decoded := make(map[uintptr]bool)
 	for _, e := range es {
-		ss := strings.Split(e, "=")
-		if len(ss) < 2 {
+		name, value, ok := strings.Cut(e, "=")
+		if !ok {
 			continue
 		}
 
-		key, ok := that.guard.IsSatisfied(ss[0])
+		key, ok := that.keyOf(name)
 		if !ok {
 			continue
 		}
 
-		that.accumulator.Add(key, ss[1])
+		if acc, ok := accumulator.(valueAccumulator); ok && that.json {
+			v := decodeJson(value)
+			if m, ok := v.(map[string]interface{}); ok {
+				decoded[reflect.ValueOf(m).Pointer()] = true
+			}
+			acc.Set(key, v)
+		} else {
+			accumulator.Add(key, value)
+		}
+	}
+
+	data := accumulator.Result()
+	if that.lists {
+		for key, value := range data {
+			data[key] = toLists(value, decoded)
+		}
+	}
+
+	return data, nil
+}
+
+func (that *Engine) keyOf(name string) (string, bool) {
+	key := name
+	if that.guard != nil {
+		var ok bool
+		key, ok = that.guard.IsSatisfied(name)
+		if !ok {
+			return "", false
+		}
+	}
+
+	if that.lowerCase {
+		key = strings.ToLower(key)
 	}
 
-	return that.accumulator.Result(), nil
+	return key, true
+}
+
+type valueAccumulator interface {
+	Set(key string, value interface{})
+}
+
+// decodeJson decodes values, that look like json objects or arrays.
+func decodeJson(value string) interface{} {
+	s := strings.TrimSpace(value)
+	if !(strings.HasPrefix(s, "{") && strings.HasSuffix(s, "}")) &&
+		!(strings.HasPrefix(s, "[") && strings.HasSuffix(s, "]")) {
+		return value
+	}
+
+	var res interface{}
+	if err := json.Unmarshal([]byte(s), &res); err != nil {
+		return value
+	}
+	return res
+}
+
+// toLists converts nested maps with keys 0..n-1 into lists. Decoded json objects are skipped.
+func toLists(value interface{}, decoded map[uintptr]bool) interface{} {
+	data, ok := value.(map[string]interface{})
+	if !ok || decoded[reflect.ValueOf(data).Pointer()] {
+		return value
+	}
+
+	for key, item := range data {
+		data[key] = toLists(item, decoded)
+	}
+
+	indexes := make([]int, 0, len(data))
+	for key := range data {
+		index, err := strconv.Atoi(key)
+		if err != nil || index < 0 || strconv.Itoa(index) != key {
+			return data
+		}
+		indexes = append(indexes, index)
+	}
+	if len(indexes) == 0 {
+		return data
+	}
+
+	sort.Ints(indexes)
+	if indexes[len(indexes)-1] != len(indexes)-1 {
+		return data
+	}
+
+	list := make([]interface{}, len(indexes))
+	for _, index := range indexes {
+		list[index] = data[strconv.Itoa(index)]
+	}
+	return list
 }
@@ -0,0 +1,109 @@
+package envFetcher
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEngine_Fetch(t *testing.T) {
+	environ := []string{
+		"APP_DSN=postgres://host/db?sslmode=disable&opt=a=b",
+		"APP_SERVERS_0_HOST=alpha",
+		"APP_SERVERS_1_HOST=beta",
+		"APP_SERVERS_1_PORT=8081",
+		"APP_LIMITS={\"cpu\": 2}",
+		"APP_TAGS=[\"a\",\"b\"]",
+		"APP_INDEXED={\"0\": \"a\", \"1\": \"b\"}",
+		"APP_BROKEN={oops",
+		"OTHER=skip",
+		"INVALID",
+	}
+
+	engine, err := NewBuilder().
+		WithGuard(NewPrefixGuard("APP_")).
+		WithDelimiter("_").
+		WithLowerCase(true).
+		WithLists(true).
+		WithJson(true).
+		WithEnviron(func() []string { return environ }).
+		Build()
+	require.NoError(t, err)
+
+	data, err := engine.Fetch()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"dsn": "postgres://host/db?sslmode=disable&opt=a=b",
+		"servers": []interface{}{
+			map[string]interface{}{"host": "alpha"},
+			map[string]interface{}{"host": "beta", "port": "8081"},
+		},
+		"limits":  map[string]interface{}{"cpu": float64(2)},
+		"tags":    []interface{}{"a", "b"},
+		"indexed": map[string]interface{}{"0": "a", "1": "b"},
+		"broken":  "{oops",
+	}, data)
+
+	environ = []string{"APP_DSN=other"}
+	data, err = engine.Fetch()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"dsn": "other"}, data)
+}
+
+func TestEngine_Flat(t *testing.T) {
+	engine, err := NewBuilder().
+		WithEnviron(func() []string { return []string{"A_B=1=2", "C="} }).
+		Build()
+	require.NoError(t, err)
+
+	data, err := engine.Fetch()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"A_B": "1=2", "C": ""}, data)
+}
+
+func TestEngine_SparseList(t *testing.T) {
+	engine := &Engine{delimiter: "_", lists: true}
+
+	data, err := engine.fetch([]string{"ITEMS_0=a", "ITEMS_2=c"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"ITEMS": map[string]interface{}{"0": "a", "2": "c"},
+	}, data)
+}
+
+func TestBuilder(t *testing.T) {
+	_, err := NewBuilder().WithLists(true).Build()
+	assert.ErrorIs(t, err, ErrDelimiterRequired)
+
+	_, err = NewBuilder().WithLists(true).WithDelimiter("__").Build()
+	assert.NoError(t, err)
+}
+
+func TestGuards(t *testing.T) {
+	tests := []struct {
+		name    string
+		guard   Guard
+		text    string
+		key     string
+		matched bool
+	}{
+		{name: "prefix", guard: NewPrefixGuard("APP_"), text: "APP_PORT", key: "PORT", matched: true},
+		{name: "regex group", guard: NewRegexGuard(regexp.MustCompile(`^APP_(\w+)$`)), text: "APP_PORT", key: "PORT", matched: true},
+		{name: "regex whole", guard: NewRegexGuard(regexp.MustCompile(`^APP_`)), text: "APP_PORT", key: "APP_PORT", matched: true},
+		{name: "regex mismatch", guard: NewRegexGuard(regexp.MustCompile(`^APP_`)), text: "HOME"},
+		{name: "allowlist", guard: NewAllowlistGuard("HOME", "PATH"), text: "HOME", key: "HOME", matched: true},
+		{name: "allowlist mismatch", guard: NewAllowlistGuard("HOME"), text: "USER"},
+		{name: "chain", guard: NewChainGuard(NewPrefixGuard("APP_"), NewAllowlistGuard("PORT")), text: "APP_PORT", key: "PORT", matched: true},
+		{name: "chain mismatch", guard: NewChainGuard(NewPrefixGuard("APP_"), NewAllowlistGuard("PORT")), text: "APP_HOST"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, matched := tt.guard.IsSatisfied(tt.text)
+			assert.Equal(t, tt.matched, matched)
+			assert.Equal(t, tt.key, key)
+		})
+	}
+}
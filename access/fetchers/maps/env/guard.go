@@ -1,6 +1,15 @@
 package envFetcher
 
-import "strings"
+import (
+	"regexp"
+	"strings"
+)
+
+type GuardFunc func(text string) (key string, matched bool)
+
+func (fn GuardFunc) IsSatisfied(text string) (key string, matched bool) {
+	return fn(text)
+}
 
 type PrefixGuard struct {
 	prefix string
@@ -19,3 +28,76 @@ func (that *PrefixGuard) IsSatisfied(text string) (key string, matched bool) {
 
 	return "", false
 }
+
+// RegexGuard accepts names, that match the regular expression.
+// Key is the first submatch (or the whole name, if expression has no groups):
+//
+//	NewRegexGuard(regexp.MustCompile(`^APP_(.+)$`))
+type RegexGuard struct {
+	re *regexp.Regexp
+}
+
+func NewRegexGuard(re *regexp.Regexp) *RegexGuard {
+	return &RegexGuard{
+		re: re,
+	}
+}
+
+func (that *RegexGuard) IsSatisfied(text string) (key string, matched bool) {
+	matches := that.re.FindStringSubmatch(text)
+	if matches == nil {
+		return "", false
+	}
+
+	if len(matches) > 1 {
+		return matches[1], true
+	}
+
+	return text, true
+}
+
+// AllowlistGuard accepts only listed names.
+type AllowlistGuard struct {
+	names map[string]struct{}
+}
+
+func NewAllowlistGuard(names ...string) *AllowlistGuard {
+	guard := &AllowlistGuard{
+		names: make(map[string]struct{}, len(names)),
+	}
+	for _, name := range names {
+		guard.names[name] = struct{}{}
+	}
+	return guard
+}
+
+func (that *AllowlistGuard) IsSatisfied(text string) (key string, matched bool) {
+	if _, ok := that.names[text]; ok {
+		return text, true
+	}
+
+	return "", false
+}
+
+// ChainGuard applies guards one by one. Each guard receives key of the previous guard.
+type ChainGuard struct {
+	guards []Guard
+}
+
+func NewChainGuard(guards ...Guard) *ChainGuard {
+	return &ChainGuard{
+		guards: guards,
+	}
+}
+
+func (that *ChainGuard) IsSatisfied(text string) (key string, matched bool) {
+	key = text
+	for _, guard := range that.guards {
+		key, matched = guard.IsSatisfied(key)
+		if !matched {
+			return "", false
+		}
+	}
+
+	return key, true
+}
@@ -3,6 +3,9 @@ package jsonFetcher
 import (
 	"bytes"
 	"encoding/json"
+	"strconv"
+	"strings"
+	"sync"
 )
 
 type Writer interface {
@@ -13,8 +16,15 @@ type Fetcher interface {
 	Fetch() ([]byte, error)
 }
 
+type Named interface {
+	Filename() string
+}
+
 type Engine struct {
+	mx      sync.Mutex
 	fetcher Fetcher
+	source  []byte
+	offsets map[string]int
 }
 
 func New(fetcher Fetcher) *Engine {
@@ -31,6 +41,8 @@ func (that *Engine) Fetch() (map[string]interface{}, error) {
 		return nil, err
 	}
 
+	that.setSource(source)
+
 	if len(source) == 0 {
 		return data, nil
 	}
@@ -44,6 +56,29 @@ func (that *Engine) Fetch() (map[string]interface{}, error) {
 	return data, nil
 }
 
+// Locate returns position of the key in the last fetched document.
+// Items of lists are addressed by index.
+func (that *Engine) Locate(path []string) (file string, line, column int, ok bool) {
+	if named, ok := that.fetcher.(Named); ok {
+		file = named.Filename()
+	}
+
+	that.mx.Lock()
+	defer that.mx.Unlock()
+
+	if that.offsets == nil && len(that.source) != 0 {
+		that.offsets = offsetsOf(that.source)
+	}
+
+	offset, ok := that.offsets[pathKey(path)]
+	if !ok {
+		return file, 0, 0, false
+	}
+
+	line, column = positionOf(that.source, offset)
+	return file, line, column, true
+}
+
 func (that *Engine) Save(data map[string]interface{}) error {
 	if writer, ok := that.fetcher.(Writer); ok {
 		bytes, err := json.Marshal(data)
@@ -55,3 +90,85 @@ func (that *Engine) Save(data map[string]interface{}) error {
 
 	return nil
 }
+
+func (that *Engine) setSource(source []byte) {
+	that.mx.Lock()
+	defer that.mx.Unlock()
+
+	that.source = source
+	that.offsets = nil
+}
+
+// offsetsOf indexes offsets of keys and items of lists in the document.
+func offsetsOf(source []byte) map[string]int {
+	offsets := make(map[string]int)
+	decoder := json.NewDecoder(bytes.NewReader(source))
+	_ = indexValue(decoder, source, nil, offsets)
+	return offsets
+}
+
+func indexValue(decoder *json.Decoder, source []byte, path []string, offsets map[string]int) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+
+	delim, ok := token.(json.Delim)
+	if !ok {
+		return nil
+	}
+
+	switch delim {
+	case '{':
+		for decoder.More() {
+			offset := skipSeparators(source, int(decoder.InputOffset()))
+			token, err := decoder.Token()
+			if err != nil {
+				return err
+			}
+			key, _ := token.(string)
+			p := append(path[:len(path):len(path)], key)
+			offsets[pathKey(p)] = offset
+			if err := indexValue(decoder, source, p, offsets); err != nil {
+				return err
+			}
+		}
+	case '[':
+		for i := 0; decoder.More(); i++ {
+			offset := skipSeparators(source, int(decoder.InputOffset()))
+			p := append(path[:len(path):len(path)], strconv.Itoa(i))
+			offsets[pathKey(p)] = offset
+			if err := indexValue(decoder, source, p, offsets); err != nil {
+				return err
+			}
+		}
+	}
+
+	// closing delimiter
+	_, err = decoder.Token()
+	return err
+}
+
+func skipSeparators(source []byte, offset int) int {
+	for offset < len(source) && strings.IndexByte(" \t\r\n,:", source[offset]) >= 0 {
+		offset++
+	}
+	return offset
+}
+
+func positionOf(source []byte, offset int) (line, column int) {
+	line, column = 1, 1
+	for _, c := range source[:offset] {
+		if c == '\n' {
+			line++
+			column = 1
+		} else {
+			column++
+		}
+	}
+	return line, column
+}
+
+func pathKey(path []string) string {
+	return strings.Join(path, "\x00")
+}
@@ -0,0 +1,89 @@
+package sqlFetcher
+
+import (
+	"errors"
+	"time"
+
+	"github.com/adverax/metacrm.kernel/database/sql"
+)
+
+type Builder struct {
+	engine *Engine
+}
+
+func NewBuilder() *Builder {
+	return &Builder{
+		engine: &Engine{
+			table:       "settings",
+			keyColumn:   "key",
+			valueColumn: "value",
+			delimiter:   ".",
+			timeout:     10 * time.Second,
+		},
+	}
+}
+
+func (that *Builder) WithScope(scope sql.Scope) *Builder {
+	that.engine.scope = scope
+	return that
+}
+
+// WithTable sets table of settings (default "settings"). Schema is allowed: "config.settings".
+func (that *Builder) WithTable(table string) *Builder {
+	that.engine.table = table
+	return that
+}
+
+// WithColumns sets columns of key and value (default "key" and "value").
+func (that *Builder) WithColumns(key, value string) *Builder {
+	that.engine.keyColumn = key
+	that.engine.valueColumn = value
+	return that
+}
+
+// WithTenant selects common rows (tenant is NULL) and rows of the tenant.
+// Rows of the tenant override common rows.
+func (that *Builder) WithTenant(column string, tenant interface{}) *Builder {
+	that.engine.tenantColumn = column
+	that.engine.tenant = tenant
+	return that
+}
+
+// WithDelimiter sets delimiter of nested keys (default ".").
+func (that *Builder) WithDelimiter(delimiter string) *Builder {
+	that.engine.delimiter = delimiter
+	return that
+}
+
+func (that *Builder) WithTimeout(timeout time.Duration) *Builder {
+	that.engine.timeout = timeout
+	return that
+}
+
+func (that *Builder) Build() (*Engine, error) {
+	if err := that.checkRequiredFields(); err != nil {
+		return nil, err
+	}
+
+	that.engine.query = that.engine.makeQuery()
+	return that.engine, nil
+}
+
+func (that *Builder) checkRequiredFields() error {
+	if that.engine.scope == nil {
+		return ErrScopeRequired
+	}
+	if that.engine.table == "" {
+		return ErrTableRequired
+	}
+	if that.engine.keyColumn == "" || that.engine.valueColumn == "" {
+		return ErrColumnsRequired
+	}
+	return nil
+}
+
+var (
+	ErrScopeRequired   = errors.New("scope is required")
+	ErrTableRequired   = errors.New("table is required")
+	ErrColumnsRequired = errors.New("columns are required")
+)
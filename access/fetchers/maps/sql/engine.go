@@ -0,0 +1,79 @@
+package sqlFetcher
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	envFetcher "github.com/adverax/metacrm.kernel/access/fetchers/maps/env"
+	"github.com/adverax/metacrm.kernel/database/sql"
+	"github.com/jackc/pgx/v5"
+)
+
+// Engine reads settings from table of key/value rows.
+// Dotted keys are converted into nested map: "address.port" => {"address": {"port": ...}}.
+// Changes are detected by polling (see configs.Watcher) or by Listen.
+type Engine struct {
+	scope        sql.Scope
+	table        string
+	keyColumn    string
+	valueColumn  string
+	tenantColumn string
+	tenant       interface{}
+	delimiter    string
+	timeout      time.Duration
+	query        string
+}
+
+func (that *Engine) Fetch() (map[string]interface{}, error) {
+	ctx := context.Background()
+	if that.timeout != 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, that.timeout)
+		defer cancel()
+	}
+
+	var args []interface{}
+	if that.tenantColumn != "" {
+		args = append(args, that.tenant)
+	}
+
+	accumulator := envFetcher.NewKeyPathAccumulator(that.delimiter)
+	err := that.scope.Fetch(ctx, that.query, args...)(func(rows sql.Rows) error {
+		var key string
+		var value sql.NullString
+		if err := rows.Scan(&key, &value); err != nil {
+			return err
+		}
+		if value.Valid {
+			accumulator.Add(key, value.String)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetch settings: %w", err)
+	}
+
+	return accumulator.Result(), nil
+}
+
+func (that *Engine) makeQuery() string {
+	var query strings.Builder
+	_, _ = fmt.Fprintf(
+		&query,
+		"SELECT %s, %s FROM %s",
+		pgx.Identifier{that.keyColumn}.Sanitize(),
+		pgx.Identifier{that.valueColumn}.Sanitize(),
+		pgx.Identifier(strings.Split(that.table, ".")).Sanitize(),
+	)
+
+	if that.tenantColumn != "" {
+		tenant := pgx.Identifier{that.tenantColumn}.Sanitize()
+		_, _ = fmt.Fprintf(&query, " WHERE %s IS NULL OR %s = $1 ORDER BY %s NULLS FIRST, 1", tenant, tenant, tenant)
+	} else {
+		query.WriteString(" ORDER BY 1")
+	}
+
+	return query.String()
+}
@@ -0,0 +1,77 @@
+package sqlFetcher
+
+import (
+	"context"
+	"testing"
+
+	"github.com/adverax/metacrm.kernel/database/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeRows struct {
+	sql.Rows
+	row []interface{}
+}
+
+func (that *fakeRows) Scan(dest ...interface{}) error {
+	*dest[0].(*string) = that.row[0].(string)
+	return dest[1].(*sql.NullString).Scan(that.row[1])
+}
+
+type fakeScope struct {
+	sql.Scope
+	rows  [][]interface{}
+	query string
+	args  []interface{}
+}
+
+func (that *fakeScope) Fetch(ctx context.Context, query string, args ...any) sql.Fetcher {
+	that.query = query
+	that.args = args
+	return func(scan func(rows sql.Rows) error) error {
+		for _, row := range that.rows {
+			if err := scan(&fakeRows{row: row}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func TestEngine_Fetch(t *testing.T) {
+	scope := &fakeScope{
+		rows: [][]interface{}{
+			{"address.host", "localhost"},
+			{"address.port", "80"},
+			{"name", nil},
+			{"address.port", "8080"},
+		},
+	}
+
+	engine, err := NewBuilder().
+		WithScope(scope).
+		WithTable("config.settings").
+		WithTenant("tenant_id", 7).
+		Build()
+	require.NoError(t, err)
+
+	data, err := engine.Fetch()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"address": map[string]interface{}{
+			"host": "localhost",
+			"port": "8080",
+		},
+	}, data)
+	assert.Equal(t, `SELECT "key", "value" FROM "config"."settings" WHERE "tenant_id" IS NULL OR "tenant_id" = $1 ORDER BY "tenant_id" NULLS FIRST, 1`, scope.query)
+	assert.Equal(t, []interface{}{7}, scope.args)
+
+	// Each fetch starts from scratch
+	scope.rows = scope.rows[:1]
+	data, err = engine.Fetch()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"address": map[string]interface{}{"host": "localhost"},
+	}, data)
+}
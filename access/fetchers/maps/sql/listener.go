@@ -0,0 +1,72 @@
+package sqlFetcher
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Listen waits for notifications of the channel (LISTEN/NOTIFY) and calls handler
+// for each notification (for example configs.Watcher.Notify).
+// Connection is restored after errors. Function returns, when context is done.
+//
+// Settings table can notify about changes by trigger:
+//
+//	CREATE FUNCTION notify_settings() RETURNS trigger AS $$
+//	BEGIN
+//	  PERFORM pg_notify('settings', '');
+//	  RETURN NULL;
+//	END;
+//	$$ LANGUAGE plpgsql;
+//
+//	CREATE TRIGGER settings_changed AFTER INSERT OR UPDATE OR DELETE ON settings
+//	FOR EACH STATEMENT EXECUTE FUNCTION notify_settings();
+func Listen(ctx context.Context, pool *pgxpool.Pool, channel string, handler func(payload string), errorHandler func(err error)) {
+	for ctx.Err() == nil {
+		err := listen(ctx, pool, channel, handler)
+		if err == nil || ctx.Err() != nil {
+			return
+		}
+
+		if errorHandler != nil {
+			errorHandler(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(reconnectDelay):
+		}
+	}
+}
+
+func listen(ctx context.Context, pool *pgxpool.Pool, channel string, handler func(payload string)) error {
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	_, err = conn.Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize())
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_, _ = conn.Exec(context.Background(), "UNLISTEN "+pgx.Identifier{channel}.Sanitize())
+	}()
+
+	// Changes, made while connection was lost, must not be missed
+	handler("")
+
+	for {
+		notification, err := conn.Conn().WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		handler(notification.Payload)
+	}
+}
+
+var reconnectDelay = time.Second
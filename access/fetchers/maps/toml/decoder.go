@@ -0,0 +1,733 @@
+package tomlFetcher
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Unmarshal parses TOML document into the map.
+// Tables are represented as map[string]interface{}, arrays as []interface{},
+// integers as int64, floats as float64 and date-times as time.Time.
+// Local times (without date) are represented as strings.
+func Unmarshal(data []byte) (map[string]interface{}, error) {
+	p := &decoder{
+		src:     string(data),
+		line:    1,
+		root:    make(map[string]interface{}),
+		tables:  make(map[string]bool),
+		arrays:  make(map[string]bool),
+		inlines: make(map[string]bool),
+		frozen:  make(map[string]bool),
+	}
+	p.current = p.root
+
+	if err := p.parse(); err != nil {
+		return nil, err
+	}
+
+	return p.root, nil
+}
+
+type decoder struct {
+	src     string
+	pos     int
+	line    int
+	root    map[string]interface{}
+	current map[string]interface{}
+	path    []string
+	tables  map[string]bool // explicitly defined tables
+	arrays  map[string]bool // arrays of tables
+	inlines map[string]bool // inline tables and dotted keys (sealed for headers)
+	frozen  map[string]bool // inline tables (sealed for headers and dotted keys)
+}
+
+func (that *decoder) parse() error {
+	for {
+		that.skipBlank()
+		if that.eof() {
+			return nil
+		}
+
+		var err error
+		if that.peek() == '[' {
+			err = that.parseHeader()
+		} else {
+			err = that.parseKeyValue(that.current, that.path)
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := that.expectLineEnd(); err != nil {
+			return err
+		}
+	}
+}
+
+func (that *decoder) parseHeader() error {
+	that.pos++
+	array := false
+	if that.peek() == '[' {
+		array = true
+		that.pos++
+	}
+
+	that.skipSpaces()
+	keys, err := that.parseKey()
+	if err != nil {
+		return err
+	}
+	that.skipSpaces()
+
+	if !that.consume("]") || (array && !that.consume("]")) {
+		return that.errorf("unterminated table header")
+	}
+
+	parent, err := that.descend(that.root, keys[:len(keys)-1], nil)
+	if err != nil {
+		return err
+	}
+
+	key := keys[len(keys)-1]
+	name := joinKey(keys)
+
+	if array {
+		table := make(map[string]interface{})
+		switch v := parent[key].(type) {
+		case nil:
+			parent[key] = []interface{}{table}
+			that.arrays[name] = true
+		case []interface{}:
+			if !that.arrays[name] {
+				return that.errorf("key %q is already defined as array", name)
+			}
+			parent[key] = append(v, table)
+		default:
+			return that.errorf("key %q is already defined", name)
+		}
+		that.forgetNested(name)
+		that.current = table
+		that.path = keys
+		return nil
+	}
+
+	if that.tables[name] || that.inlines[name] || that.arrays[name] {
+		return that.errorf("table %q is already defined", name)
+	}
+
+	switch v := parent[key].(type) {
+	case nil:
+		table := make(map[string]interface{})
+		parent[key] = table
+		that.current = table
+	case map[string]interface{}:
+		that.current = v
+	default:
+		return that.errorf("key %q is already defined", name)
+	}
+
+	that.tables[name] = true
+	that.path = keys
+	return nil
+}
+
+// forgetNested drops tables definitions of the previous element of array.
+func (that *decoder) forgetNested(name string) {
+	prefix := name + "."
+	for _, defs := range []map[string]bool{that.tables, that.arrays, that.inlines, that.frozen} {
+		for k := range defs {
+			if strings.HasPrefix(k, prefix) {
+				delete(defs, k)
+			}
+		}
+	}
+}
+
+// descend walks through tables by keys and creates missing (implicit) tables.
+// If sealed is not nil, then all traversed tables are marked as defined by dotted keys.
+func (that *decoder) descend(
+	table map[string]interface{},
+	keys []string,
+	sealed []string,
+) (map[string]interface{}, error) {
+	for i, key := range keys {
+		switch v := table[key].(type) {
+		case nil:
+			next := make(map[string]interface{})
+			table[key] = next
+			table = next
+		case map[string]interface{}:
+			name := joinKey(append(sealed, keys[:i+1]...))
+			if that.frozen[name] || sealed != nil && that.tables[name] {
+				return nil, that.errorf("table %q is already defined", joinKey(keys[:i+1]))
+			}
+			table = v
+		case []interface{}:
+			name := joinKey(append(sealed, keys[:i+1]...))
+			if sealed != nil || !that.arrays[name] || len(v) == 0 {
+				return nil, that.errorf("key %q is already defined as array", name)
+			}
+			last, ok := v[len(v)-1].(map[string]interface{})
+			if !ok {
+				return nil, that.errorf("key %q is already defined as array", name)
+			}
+			table = last
+		default:
+			return nil, that.errorf("key %q is already defined", joinKey(keys[:i+1]))
+		}
+
+		if sealed != nil {
+			that.inlines[joinKey(append(sealed, keys[:i+1]...))] = true
+		}
+	}
+	return table, nil
+}
+
+func (that *decoder) parseKeyValue(table map[string]interface{}, path []string) error {
+	keys, err := that.parseKey()
+	if err != nil {
+		return err
+	}
+
+	that.skipSpaces()
+	if !that.consume("=") {
+		return that.errorf("expected '=' after key")
+	}
+	that.skipSpaces()
+
+	scope := append(append([]string{}, path...), keys[:len(keys)-1]...)
+	target, err := that.descend(table, keys[:len(keys)-1], append([]string{}, path...))
+	if err != nil {
+		return err
+	}
+
+	key := keys[len(keys)-1]
+	if _, exists := target[key]; exists {
+		return that.errorf("key %q is already defined", joinKey(append(scope, key)))
+	}
+
+	value, err := that.parseValue(append(scope, key))
+	if err != nil {
+		return err
+	}
+
+	target[key] = value
+	return nil
+}
+
+func (that *decoder) parseKey() ([]string, error) {
+	var keys []string
+	for {
+		key, err := that.parseSimpleKey()
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+
+		that.skipSpaces()
+		if that.peek() != '.' {
+			return keys, nil
+		}
+		that.pos++
+		that.skipSpaces()
+	}
+}
+
+func (that *decoder) parseSimpleKey() (string, error) {
+	switch that.peek() {
+	case '"':
+		return that.parseBasicString()
+	case '\'':
+		return that.parseLiteralString()
+	}
+
+	start := that.pos
+	for !that.eof() && isBareKeyChar(that.peek()) {
+		that.pos++
+	}
+	if start == that.pos {
+		return "", that.errorf("expected key")
+	}
+	return that.src[start:that.pos], nil
+}
+
+func (that *decoder) parseValue(path []string) (interface{}, error) {
+	switch {
+	case that.hasPrefix(`"""`):
+		return that.parseMultilineBasicString()
+	case that.hasPrefix(`'''`):
+		return that.parseMultilineLiteralString()
+	case that.peek() == '"':
+		return that.parseBasicString()
+	case that.peek() == '\'':
+		return that.parseLiteralString()
+	case that.peek() == '[':
+		return that.parseArray(path)
+	case that.peek() == '{':
+		return that.parseInlineTable(path)
+	case that.hasPrefix("true") && !that.isBareAt(that.pos+4):
+		that.pos += 4
+		return true, nil
+	case that.hasPrefix("false") && !that.isBareAt(that.pos+5):
+		that.pos += 5
+		return false, nil
+	}
+
+	return that.parseScalar()
+}
+
+func (that *decoder) parseArray(path []string) (interface{}, error) {
+	that.pos++
+	res := make([]interface{}, 0)
+	for {
+		that.skipBlank()
+		if that.consume("]") {
+			return res, nil
+		}
+
+		value, err := that.parseValue(path)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, value)
+
+		that.skipBlank()
+		if that.consume("]") {
+			return res, nil
+		}
+		if !that.consume(",") {
+			return nil, that.errorf("expected ',' or ']' in array")
+		}
+	}
+}
+
+func (that *decoder) parseInlineTable(path []string) (interface{}, error) {
+	that.pos++
+	res := make(map[string]interface{})
+	that.inlines[joinKey(path)] = true
+	that.frozen[joinKey(path)] = true
+
+	that.skipSpaces()
+	if that.consume("}") {
+		return res, nil
+	}
+
+	for {
+		that.skipSpaces()
+		if err := that.parseKeyValue(res, path); err != nil {
+			return nil, err
+		}
+
+		that.skipSpaces()
+		if that.consume("}") {
+			return res, nil
+		}
+		if !that.consume(",") {
+			return nil, that.errorf("expected ',' or '}' in inline table")
+		}
+	}
+}
+
+func (that *decoder) parseBasicString() (string, error) {
+	that.pos++
+	var buf strings.Builder
+	for {
+		if that.eof() || that.peek() == '\n' {
+			return "", that.errorf("unterminated string")
+		}
+
+		ch := that.peek()
+		switch ch {
+		case '"':
+			that.pos++
+			return buf.String(), nil
+		case '\\':
+			if err := that.parseEscape(&buf); err != nil {
+				return "", err
+			}
+		default:
+			buf.WriteByte(ch)
+			that.pos++
+		}
+	}
+}
+
+func (that *decoder) parseMultilineBasicString() (string, error) {
+	that.pos += 3
+	that.skipNewline()
+
+	var buf strings.Builder
+	for {
+		if that.eof() {
+			return "", that.errorf("unterminated string")
+		}
+
+		if that.hasPrefix(`"""`) {
+			that.pos += 3
+			for i := 0; i < 2 && that.peek() == '"'; i++ {
+				buf.WriteByte('"')
+				that.pos++
+			}
+			return buf.String(), nil
+		}
+
+		ch := that.peek()
+		switch ch {
+		case '\\':
+			if that.isLineEndingBackslash() {
+				that.pos++
+				for !that.eof() && strings.IndexByte(" \t\r\n", that.peek()) >= 0 {
+					if that.peek() == '\n' {
+						that.line++
+					}
+					that.pos++
+				}
+				continue
+			}
+			if err := that.parseEscape(&buf); err != nil {
+				return "", err
+			}
+		case '\n':
+			that.line++
+			buf.WriteByte(ch)
+			that.pos++
+		default:
+			buf.WriteByte(ch)
+			that.pos++
+		}
+	}
+}
+
+func (that *decoder) isLineEndingBackslash() bool {
+	for i := that.pos + 1; i < len(that.src); i++ {
+		switch that.src[i] {
+		case ' ', '\t', '\r':
+		case '\n':
+			return true
+		default:
+			return false
+		}
+	}
+	return false
+}
+
+func (that *decoder) parseEscape(buf *strings.Builder) error {
+	that.pos++
+	if that.eof() {
+		return that.errorf("unterminated escape sequence")
+	}
+
+	ch := that.peek()
+	that.pos++
+	switch ch {
+	case 'b':
+		buf.WriteByte('\b')
+	case 't':
+		buf.WriteByte('\t')
+	case 'n':
+		buf.WriteByte('\n')
+	case 'f':
+		buf.WriteByte('\f')
+	case 'r':
+		buf.WriteByte('\r')
+	case 'e':
+		buf.WriteByte(0x1b)
+	case '"':
+		buf.WriteByte('"')
+	case '\\':
+		buf.WriteByte('\\')
+	case 'u', 'U':
+		size := 4
+		if ch == 'U' {
+			size = 8
+		}
+		if that.pos+size > len(that.src) {
+			return that.errorf("invalid unicode escape")
+		}
+		code, err := strconv.ParseUint(that.src[that.pos:that.pos+size], 16, 32)
+		if err != nil || !utf8.ValidRune(rune(code)) {
+			return that.errorf("invalid unicode escape")
+		}
+		buf.WriteRune(rune(code))
+		that.pos += size
+	default:
+		return that.errorf("invalid escape sequence \\%c", ch)
+	}
+	return nil
+}
+
+func (that *decoder) parseLiteralString() (string, error) {
+	that.pos++
+	end := strings.IndexAny(that.src[that.pos:], "'\n")
+	if end < 0 || that.src[that.pos+end] != '\'' {
+		return "", that.errorf("unterminated string")
+	}
+
+	res := that.src[that.pos : that.pos+end]
+	that.pos += end + 1
+	return res, nil
+}
+
+func (that *decoder) parseMultilineLiteralString() (string, error) {
+	that.pos += 3
+	that.skipNewline()
+
+	end := strings.Index(that.src[that.pos:], `'''`)
+	if end < 0 {
+		return "", that.errorf("unterminated string")
+	}
+	end += that.pos + 3
+	for i := 0; i < 2 && end < len(that.src) && that.src[end] == '\''; i++ {
+		end++
+	}
+
+	res := that.src[that.pos : end-3]
+	that.line += strings.Count(res, "\n")
+	that.pos = end
+	return res, nil
+}
+
+func (that *decoder) parseScalar() (interface{}, error) {
+	start := that.pos
+	for !that.eof() && isScalarChar(that.peek()) {
+		that.pos++
+	}
+
+	// Date and time can be separated by space
+	if that.pos-start == 10 && that.peek() == ' ' && that.pos+1 < len(that.src) && isDigit(that.src[that.pos+1]) {
+		that.pos++
+		for !that.eof() && isScalarChar(that.peek()) {
+			that.pos++
+		}
+	}
+
+	token := that.src[start:that.pos]
+	if token == "" {
+		return nil, that.errorf("expected value")
+	}
+
+	if value, ok := parseDateTime(token); ok {
+		return value, nil
+	}
+
+	if value, ok := parseNumber(token); ok {
+		return value, nil
+	}
+
+	return nil, that.errorf("invalid value %q", token)
+}
+
+func (that *decoder) expectLineEnd() error {
+	that.skipSpaces()
+	if that.peek() == '#' {
+		that.skipComment()
+	}
+
+	if that.eof() {
+		return nil
+	}
+
+	if that.consume("\r\n") || that.consume("\n") {
+		that.line++
+		return nil
+	}
+
+	return that.errorf("expected new line")
+}
+
+func (that *decoder) skipBlank() {
+	for !that.eof() {
+		switch that.peek() {
+		case ' ', '\t', '\r':
+			that.pos++
+		case '\n':
+			that.line++
+			that.pos++
+		case '#':
+			that.skipComment()
+		default:
+			return
+		}
+	}
+}
+
+func (that *decoder) skipSpaces() {
+	for !that.eof() && (that.peek() == ' ' || that.peek() == '\t') {
+		that.pos++
+	}
+}
+
+func (that *decoder) skipComment() {
+	for !that.eof() && that.peek() != '\n' {
+		that.pos++
+	}
+}
+
+func (that *decoder) skipNewline() {
+	if that.consume("\r\n") || that.consume("\n") {
+		that.line++
+	}
+}
+
+func (that *decoder) eof() bool {
+	return that.pos >= len(that.src)
+}
+
+func (that *decoder) peek() byte {
+	if that.eof() {
+		return 0
+	}
+	return that.src[that.pos]
+}
+
+func (that *decoder) hasPrefix(prefix string) bool {
+	return strings.HasPrefix(that.src[that.pos:], prefix)
+}
+
+func (that *decoder) isBareAt(pos int) bool {
+	return pos < len(that.src) && isBareKeyChar(that.src[pos])
+}
+
+func (that *decoder) consume(prefix string) bool {
+	if that.hasPrefix(prefix) {
+		that.pos += len(prefix)
+		return true
+	}
+	return false
+}
+
+func (that *decoder) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("toml: line %d: %s", that.line, fmt.Sprintf(format, args...))
+}
+
+// parseDateTime parses date-times. Local date-times and dates (without offset) are parsed in time.Local.
+func parseDateTime(token string) (interface{}, bool) {
+	if len(token) < 8 || !isDigit(token[0]) || !isDigit(token[1]) {
+		return nil, false
+	}
+
+	if token[2] == ':' {
+		if _, err := time.Parse("15:04:05.999999999", token); err == nil {
+			return token, true
+		}
+		return nil, false
+	}
+
+	if len(token) < 10 || token[4] != '-' {
+		return nil, false
+	}
+
+	norm := token
+	if len(norm) > 10 && (norm[10] == ' ' || norm[10] == 't') {
+		norm = norm[:10] + "T" + norm[11:]
+	}
+	norm = strings.Replace(norm, "z", "Z", 1)
+
+	if v, err := time.Parse(time.RFC3339Nano, norm); err == nil {
+		return v, true
+	}
+	if v, err := time.ParseInLocation("2006-01-02T15:04:05.999999999", norm, time.Local); err == nil {
+		return v, true
+	}
+	if v, err := time.ParseInLocation("2006-01-02", norm, time.Local); err == nil {
+		return v, true
+	}
+	return nil, false
+}
+
+func parseNumber(token string) (interface{}, bool) {
+	switch token {
+	case "inf", "+inf":
+		return math.Inf(1), true
+	case "-inf":
+		return math.Inf(-1), true
+	case "nan", "+nan", "-nan":
+		return math.NaN(), true
+	}
+
+	if !validUnderscores(token) {
+		return nil, false
+	}
+	clean := strings.ReplaceAll(token, "_", "")
+
+	if len(clean) > 2 && clean[0] == '0' {
+		base := 0
+		switch clean[1] {
+		case 'x':
+			base = 16
+		case 'o':
+			base = 8
+		case 'b':
+			base = 2
+		}
+		if base != 0 {
+			v, err := strconv.ParseInt(clean[2:], base, 64)
+			return v, err == nil
+		}
+	}
+
+	digits := strings.TrimLeft(clean, "+-")
+	if len(digits) > 1 && digits[0] == '0' && isDigit(digits[1]) {
+		return nil, false // leading zeros are not allowed
+	}
+
+	if strings.ContainsAny(clean, ".eE") {
+		if strings.HasPrefix(digits, ".") || strings.Contains(clean, ".e") || strings.HasSuffix(clean, ".") {
+			return nil, false
+		}
+		v, err := strconv.ParseFloat(clean, 64)
+		return v, err == nil
+	}
+
+	v, err := strconv.ParseInt(clean, 10, 64)
+	return v, err == nil
+}
+
+// validUnderscores checks, that each underscore is surrounded by digits (0x_ff and 1_e5 are invalid).
+func validUnderscores(token string) bool {
+	digit := isDigit
+	if len(token) > 2 && token[0] == '0' && strings.IndexByte("xob", token[1]) >= 0 {
+		if token[1] == 'x' {
+			digit = isHexDigit
+		}
+		token = token[2:]
+	}
+
+	for i := 0; i < len(token); i++ {
+		if token[i] != '_' {
+			continue
+		}
+		if i == 0 || i == len(token)-1 || !digit(token[i-1]) || !digit(token[i+1]) {
+			return false
+		}
+	}
+	return true
+}
+
+func joinKey(keys []string) string {
+	return strings.Join(keys, ".")
+}
+
+func isDigit(ch byte) bool {
+	return ch >= '0' && ch <= '9'
+}
+
+func isHexDigit(ch byte) bool {
+	return isDigit(ch) || (ch >= 'a' && ch <= 'f') || (ch >= 'A' && ch <= 'F')
+}
+
+func isAlnum(ch byte) bool {
+	return isDigit(ch) || (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z')
+}
+
+func isBareKeyChar(ch byte) bool {
+	return isAlnum(ch) || ch == '_' || ch == '-'
+}
+
+func isScalarChar(ch byte) bool {
+	return isAlnum(ch) || strings.IndexByte("+-_.:", ch) >= 0
+}
@@ -0,0 +1,227 @@
+package tomlFetcher
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnmarshal(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      string
+		expected map[string]interface{}
+	}{
+		{
+			name: "scalars",
+			src: `# comment
+title = "TOML \"Example\"" # trailing comment
+path = 'C:\Users'
+count = 1_000
+hex = 0xff
+negative = -17
+ratio = 3.14
+exp = 5e+2
+enabled = true
+disabled = false
+`,
+			expected: map[string]interface{}{
+				"title":    `TOML "Example"`,
+				"path":     `C:\Users`,
+				"count":    int64(1000),
+				"hex":      int64(255),
+				"negative": int64(-17),
+				"ratio":    3.14,
+				"exp":      500.0,
+				"enabled":  true,
+				"disabled": false,
+			},
+		},
+		{
+			name: "multiline strings",
+			src: "basic = \"\"\"\nRoses are red\nViolets are blue\"\"\"\n" +
+				"folded = \"\"\"\\\n  The quick \\\n  brown fox\"\"\"\n" +
+				"literal = '''\nraw \\n text'''\n",
+			expected: map[string]interface{}{
+				"basic":   "Roses are red\nViolets are blue",
+				"folded":  "The quick brown fox",
+				"literal": `raw \n text`,
+			},
+		},
+		{
+			name: "tables and dotted keys",
+			src: `
+name = "My App"
+address.host = "google.com"
+
+[server]
+port = 81
+
+[server."tls.options"]
+enabled = true
+
+[database.primary]
+dsn = "postgres://localhost"
+`,
+			expected: map[string]interface{}{
+				"name": "My App",
+				"address": map[string]interface{}{
+					"host": "google.com",
+				},
+				"server": map[string]interface{}{
+					"port": int64(81),
+					"tls.options": map[string]interface{}{
+						"enabled": true,
+					},
+				},
+				"database": map[string]interface{}{
+					"primary": map[string]interface{}{
+						"dsn": "postgres://localhost",
+					},
+				},
+			},
+		},
+		{
+			name: "arrays and inline tables",
+			src: `
+ports = [ 8000, 8001,
+  8002, # comment
+]
+point = { x = 1, y = 2 }
+nested = [[1, 2], ["a"]]
+`,
+			expected: map[string]interface{}{
+				"ports": []interface{}{int64(8000), int64(8001), int64(8002)},
+				"point": map[string]interface{}{
+					"x": int64(1),
+					"y": int64(2),
+				},
+				"nested": []interface{}{
+					[]interface{}{int64(1), int64(2)},
+					[]interface{}{"a"},
+				},
+			},
+		},
+		{
+			name: "array of tables",
+			src: `
+[[servers]]
+host = "alpha"
+
+[[servers]]
+host = "beta"
+
+[servers.tls]
+enabled = true
+`,
+			expected: map[string]interface{}{
+				"servers": []interface{}{
+					map[string]interface{}{"host": "alpha"},
+					map[string]interface{}{
+						"host": "beta",
+						"tls":  map[string]interface{}{"enabled": true},
+					},
+				},
+			},
+		},
+		{
+			name: "date-times",
+			src: `
+odt = 1979-05-27T07:32:00Z
+spaced = 1979-05-27 07:32:00Z
+lt = 07:32:00
+`,
+			expected: map[string]interface{}{
+				"odt":    time.Date(1979, 5, 27, 7, 32, 0, 0, time.UTC),
+				"spaced": time.Date(1979, 5, 27, 7, 32, 0, 0, time.UTC),
+				"lt":     "07:32:00",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := Unmarshal([]byte(tt.src))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestUnmarshal_Errors(t *testing.T) {
+	tests := map[string]string{
+		"duplicate key":       "a = 1\na = 2",
+		"duplicate table":     "[a]\n[a]",
+		"table over value":    "a = 1\n[a]",
+		"table over inline":   "a = {}\n[a]",
+		"subtable of inline":  "a = {x = 1}\n[a.b]",
+		"dotted over inline":  "a = {x = 1}\na.y = 2",
+		"nested inline":       "a = {x = {y = 1}}\n[a.x.z]",
+		"missing value":       "a =",
+		"unterminated string": `a = "text`,
+		"leading zeros":       "a = 012",
+		"underscore of hex":   "a = 0x_ff",
+		"underscore of bin":   "a = 0b_1",
+		"underscore of exp":   "a = 1_e5",
+		"garbage after value": "a = 1 2",
+	}
+
+	for name, src := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Unmarshal([]byte(src))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestMarshal_LocalDateTime(t *testing.T) {
+	data, err := Unmarshal([]byte("ldt = 1979-05-27T07:32:00\nodt = 1979-05-27T07:32:00+02:00"))
+	require.NoError(t, err)
+
+	raw, err := Marshal(data)
+	require.NoError(t, err)
+	assert.Contains(t, string(raw), "ldt = 1979-05-27T07:32:00\n")
+	assert.Contains(t, string(raw), "odt = 1979-05-27T07:32:00+02:00\n")
+
+	actual, err := Unmarshal(raw)
+	require.NoError(t, err)
+	assert.True(t, data["ldt"].(time.Time).Equal(actual["ldt"].(time.Time)))
+	assert.True(t, data["odt"].(time.Time).Equal(actual["odt"].(time.Time)))
+}
+
+func TestMarshal(t *testing.T) {
+	data := map[string]interface{}{
+		"name":  "My \"App\"",
+		"ratio": 2.0,
+		"ports": []interface{}{int64(80), int64(81)},
+		"address": map[string]interface{}{
+			"host": "google.com",
+			"port": 81,
+		},
+		"servers": []interface{}{
+			map[string]interface{}{"host": "alpha"},
+			map[string]interface{}{"host": "beta"},
+		},
+	}
+
+	raw, err := Marshal(data)
+	require.NoError(t, err)
+
+	actual, err := Unmarshal(raw)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"name":  "My \"App\"",
+		"ratio": 2.0,
+		"ports": []interface{}{int64(80), int64(81)},
+		"address": map[string]interface{}{
+			"host": "google.com",
+			"port": int64(81),
+		},
+		"servers": []interface{}{
+			map[string]interface{}{"host": "alpha"},
+			map[string]interface{}{"host": "beta"},
+		},
+	}, actual)
+}
@@ -0,0 +1,236 @@
+package tomlFetcher
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Marshal encodes map into TOML document.
+func Marshal(data map[string]interface{}) ([]byte, error) {
+	buf := bytes.NewBuffer(nil)
+	if err := encodeTable(buf, nil, data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func encodeTable(buf *bytes.Buffer, path []string, table map[string]interface{}) error {
+	keys := make([]string, 0, len(table))
+	for key := range table {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var tables, arrays []string
+	for _, key := range keys {
+		value := table[key]
+		if _, ok := value.(map[string]interface{}); ok {
+			tables = append(tables, key)
+			continue
+		}
+		if isArrayOfTables(value) {
+			arrays = append(arrays, key)
+			continue
+		}
+
+		buf.WriteString(encodeKey(key))
+		buf.WriteString(" = ")
+		if err := encodeValue(buf, value); err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+		buf.WriteByte('\n')
+	}
+
+	for _, key := range tables {
+		sub := append(append([]string{}, path...), key)
+		writeHeader(buf, "[", sub, "]")
+		if err := encodeTable(buf, sub, table[key].(map[string]interface{})); err != nil {
+			return err
+		}
+	}
+
+	for _, key := range arrays {
+		sub := append(append([]string{}, path...), key)
+		for _, item := range table[key].([]interface{}) {
+			writeHeader(buf, "[[", sub, "]]")
+			if err := encodeTable(buf, sub, item.(map[string]interface{})); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func writeHeader(buf *bytes.Buffer, open string, path []string, close string) {
+	if buf.Len() != 0 {
+		buf.WriteByte('\n')
+	}
+
+	keys := make([]string, len(path))
+	for i, key := range path {
+		keys[i] = encodeKey(key)
+	}
+
+	buf.WriteString(open)
+	buf.WriteString(strings.Join(keys, "."))
+	buf.WriteString(close)
+	buf.WriteByte('\n')
+}
+
+func isArrayOfTables(value interface{}) bool {
+	list, ok := value.([]interface{})
+	if !ok || len(list) == 0 {
+		return false
+	}
+
+	for _, item := range list {
+		if _, ok := item.(map[string]interface{}); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func encodeValue(buf *bytes.Buffer, value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		return fmt.Errorf("toml: null values are not supported")
+	case string:
+		buf.WriteString(encodeString(v))
+	case bool:
+		buf.WriteString(strconv.FormatBool(v))
+	case time.Time:
+		// Local date-times are written without offset, as they are decoded
+		if v.Location() == time.Local {
+			buf.WriteString(v.Format("2006-01-02T15:04:05.999999999"))
+		} else {
+			buf.WriteString(v.Format(time.RFC3339Nano))
+		}
+	case time.Duration:
+		buf.WriteString(encodeString(v.String()))
+	case float32:
+		buf.WriteString(encodeFloat(float64(v)))
+	case float64:
+		buf.WriteString(encodeFloat(v))
+	case map[string]interface{}:
+		return encodeInlineTable(buf, v)
+	default:
+		val := reflect.ValueOf(value)
+		switch val.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			buf.WriteString(strconv.FormatInt(val.Int(), 10))
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			buf.WriteString(strconv.FormatUint(val.Uint(), 10))
+		case reflect.Slice, reflect.Array:
+			buf.WriteByte('[')
+			for i := 0; i < val.Len(); i++ {
+				if i != 0 {
+					buf.WriteString(", ")
+				}
+				if err := encodeValue(buf, val.Index(i).Interface()); err != nil {
+					return err
+				}
+			}
+			buf.WriteByte(']')
+		case reflect.String:
+			buf.WriteString(encodeString(val.String()))
+		default:
+			return fmt.Errorf("toml: unsupported type %T", value)
+		}
+	}
+	return nil
+}
+
+func encodeInlineTable(buf *bytes.Buffer, table map[string]interface{}) error {
+	keys := make([]string, 0, len(table))
+	for key := range table {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	buf.WriteByte('{')
+	for i, key := range keys {
+		if i != 0 {
+			buf.WriteByte(',')
+		}
+		buf.WriteByte(' ')
+		buf.WriteString(encodeKey(key))
+		buf.WriteString(" = ")
+		if err := encodeValue(buf, table[key]); err != nil {
+			return err
+		}
+	}
+	if len(keys) != 0 {
+		buf.WriteByte(' ')
+	}
+	buf.WriteByte('}')
+	return nil
+}
+
+func encodeFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "inf"
+	case math.IsInf(v, -1):
+		return "-inf"
+	case math.IsNaN(v):
+		return "nan"
+	}
+
+	s := strconv.FormatFloat(v, 'g', -1, 64)
+	if !strings.ContainsAny(s, ".eE") {
+		s += ".0"
+	}
+	return s
+}
+
+func encodeKey(key string) string {
+	if key == "" {
+		return `""`
+	}
+
+	for i := 0; i < len(key); i++ {
+		if !isBareKeyChar(key[i]) {
+			return encodeString(key)
+		}
+	}
+	return key
+}
+
+func encodeString(s string) string {
+	var buf strings.Builder
+	buf.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			buf.WriteString(`\"`)
+		case '\\':
+			buf.WriteString(`\\`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\t':
+			buf.WriteString(`\t`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\b':
+			buf.WriteString(`\b`)
+		case '\f':
+			buf.WriteString(`\f`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&buf, `\u%04X`, r)
+			} else {
+				buf.WriteRune(r)
+			}
+		}
+	}
+	buf.WriteByte('"')
+	return buf.String()
+}
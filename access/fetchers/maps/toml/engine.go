@@ -0,0 +1,44 @@
+package tomlFetcher
+
+type Writer interface {
+	Save(data []byte) error
+}
+
+type Fetcher interface {
+	Fetch() ([]byte, error)
+}
+
+type Engine struct {
+	fetcher Fetcher
+}
+
+func New(fetcher Fetcher) *Engine {
+	return &Engine{
+		fetcher: fetcher,
+	}
+}
+
+func (that *Engine) Fetch() (map[string]interface{}, error) {
+	source, err := that.fetcher.Fetch()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(source) == 0 {
+		return make(map[string]interface{}), nil
+	}
+
+	return Unmarshal(source)
+}
+
+func (that *Engine) Save(data map[string]interface{}) error {
+	if writer, ok := that.fetcher.(Writer); ok {
+		bytes, err := Marshal(data)
+		if err != nil {
+			return err
+		}
+		return writer.Save(bytes)
+	}
+
+	return nil
+}
@@ -2,6 +2,8 @@ package yamlFetcher
 
 import (
 	"bytes"
+	"strconv"
+	"sync"
 
 	"gopkg.in/yaml.v3"
 )
@@ -14,8 +16,14 @@ type Fetcher interface {
 	Fetch() ([]byte, error)
 }
 
+type Named interface {
+	Filename() string
+}
+
 type Engine struct {
+	mx      sync.Mutex
 	fetcher Fetcher
+	root    *yaml.Node
 }
 
 func New(fetcher Fetcher) *Engine {
@@ -33,18 +41,56 @@ func (that *Engine) Fetch() (map[string]interface{}, error) {
 	}
 
 	if len(source) == 0 {
+		that.setRoot(nil)
 		return data, nil
 	}
 
+	var root yaml.Node
 	decoder := yaml.NewDecoder(bytes.NewBuffer(source))
-	err = decoder.Decode(data)
+	err = decoder.Decode(&root)
+	if err != nil {
+		return nil, err
+	}
+
+	err = root.Decode(data)
 	if err != nil {
 		return nil, err
 	}
 
+	that.setRoot(&root)
 	return data, nil
 }
 
+// Locate returns position of the key in the last fetched document.
+// Items of lists are addressed by index.
+func (that *Engine) Locate(path []string) (file string, line, column int, ok bool) {
+	that.mx.Lock()
+	node := that.root
+	that.mx.Unlock()
+
+	if named, ok := that.fetcher.(Named); ok {
+		file = named.Filename()
+	}
+
+	if node == nil {
+		return file, 0, 0, false
+	}
+
+	if node.Kind == yaml.DocumentNode && len(node.Content) != 0 {
+		node = node.Content[0]
+	}
+
+	pos := node
+	for _, key := range path {
+		pos, node = childOf(node, key)
+		if node == nil {
+			return file, 0, 0, false
+		}
+	}
+
+	return file, pos.Line, pos.Column, true
+}
+
 func (that *Engine) Save(data map[string]interface{}) error {
 	if writer, ok := that.fetcher.(Writer); ok {
 		buf := bytes.NewBuffer(nil)
@@ -58,3 +104,33 @@ func (that *Engine) Save(data map[string]interface{}) error {
 
 	return nil
 }
+
+func (that *Engine) setRoot(root *yaml.Node) {
+	that.mx.Lock()
+	defer that.mx.Unlock()
+
+	that.root = root
+}
+
+// childOf returns node for position (key of mapping or item of sequence) and node of value.
+func childOf(node *yaml.Node, key string) (*yaml.Node, *yaml.Node) {
+	for node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == key {
+				return node.Content[i], node.Content[i+1]
+			}
+		}
+	case yaml.SequenceNode:
+		index, err := strconv.Atoi(key)
+		if err == nil && index >= 0 && index < len(node.Content) {
+			return node.Content[index], node.Content[index]
+		}
+	}
+
+	return nil, nil
+}
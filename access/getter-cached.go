@@ -0,0 +1,278 @@
+package access
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/adverax/metacrm.kernel/types"
+)
+
+// CachedGetter is Getter decorator, that caches values for TTL.
+// Missing values (types.ErrNoMatch) are cached for negative TTL, other errors are not cached.
+// Concurrent misses of the same name are collapsed into the single request.
+// The least recently used values are evicted, when size of cache exceeds the limit.
+type CachedGetter struct {
+	mx          sync.Mutex
+	getter      Getter
+	ttl         func(name string) time.Duration
+	negativeTTL time.Duration
+	maxSize     int
+	entries     map[string]*list.Element
+	lru         *list.List
+	calls       map[string]*cachedCall
+	stats       CacheStats
+}
+
+// CacheStats is statistics of cache.
+type CacheStats struct {
+	Hits      int64
+	Misses    int64
+	Evictions int64
+	Size      int
+}
+
+type cachedEntry struct {
+	name    string
+	value   interface{}
+	err     error
+	expires time.Time
+}
+
+type cachedCall struct {
+	done  chan struct{}
+	value interface{}
+	err   error
+	stale bool // call is invalidated and its result must not be cached
+}
+
+func (that *CachedGetter) GetProperty(
+	ctx context.Context,
+	name string,
+) (interface{}, error) {
+	// Lookup is counted once, even if the waiter retries after cancellation of the leading request
+	missed := false
+	for {
+		that.mx.Lock()
+		if entry, ok := that.lookup(name); ok {
+			if !missed {
+				that.stats.Hits++
+			}
+			that.mx.Unlock()
+			return entry.value, entry.err
+		}
+
+		if !missed {
+			that.stats.Misses++
+			missed = true
+		}
+
+		call, ok := that.calls[name]
+		if !ok {
+			call = &cachedCall{done: make(chan struct{})}
+			that.calls[name] = call
+			that.mx.Unlock()
+
+			that.fetch(ctx, name, call)
+			return call.value, call.err
+		}
+
+		that.mx.Unlock()
+		select {
+		case <-call.done:
+			// Cancellation of the leading request is not the error of the waiter
+			if isContextError(call.err) && ctx.Err() == nil {
+				continue
+			}
+			return call.value, call.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func (that *CachedGetter) fetch(ctx context.Context, name string, call *cachedCall) {
+	defer func() {
+		that.mx.Lock()
+		if that.calls[name] == call {
+			delete(that.calls, name)
+		}
+		if !call.stale {
+			that.store(name, call.value, call.err)
+		}
+		that.mx.Unlock()
+
+		close(call.done)
+	}()
+
+	// Error is kept for waiters, if the getter panics
+	call.err = ErrGetterPanicked
+	call.value, call.err = that.getter.GetProperty(ctx, name)
+}
+
+// Invalidate drops cached values of names.
+func (that *CachedGetter) Invalidate(names ...string) {
+	that.mx.Lock()
+	defer that.mx.Unlock()
+
+	for _, name := range names {
+		if elem, ok := that.entries[name]; ok {
+			that.remove(elem)
+		}
+		if call, ok := that.calls[name]; ok {
+			call.stale = true
+			delete(that.calls, name)
+		}
+	}
+}
+
+// InvalidateAll drops all cached values.
+func (that *CachedGetter) InvalidateAll() {
+	that.mx.Lock()
+	defer that.mx.Unlock()
+
+	that.entries = make(map[string]*list.Element)
+	that.lru.Init()
+	for _, call := range that.calls {
+		call.stale = true
+	}
+	that.calls = make(map[string]*cachedCall)
+}
+
+// Stats returns statistics of cache.
+func (that *CachedGetter) Stats() CacheStats {
+	that.mx.Lock()
+	defer that.mx.Unlock()
+
+	stats := that.stats
+	stats.Size = that.lru.Len()
+	return stats
+}
+
+func (that *CachedGetter) lookup(name string) (*cachedEntry, bool) {
+	elem, ok := that.entries[name]
+	if !ok {
+		return nil, false
+	}
+
+	entry := elem.Value.(*cachedEntry)
+	if !time.Now().Before(entry.expires) {
+		that.remove(elem)
+		return nil, false
+	}
+
+	that.lru.MoveToFront(elem)
+	return entry, true
+}
+
+func (that *CachedGetter) store(name string, value interface{}, err error) {
+	var ttl time.Duration
+	switch {
+	case err == nil:
+		ttl = that.ttl(name)
+	case errors.Is(err, types.ErrNoMatch):
+		ttl = that.negativeTTL
+	}
+	if ttl <= 0 {
+		return
+	}
+
+	if elem, ok := that.entries[name]; ok {
+		that.remove(elem)
+	}
+
+	entry := &cachedEntry{
+		name:    name,
+		value:   value,
+		err:     err,
+		expires: time.Now().Add(ttl),
+	}
+	that.entries[name] = that.lru.PushFront(entry)
+
+	for that.maxSize > 0 && that.lru.Len() > that.maxSize {
+		that.remove(that.lru.Back())
+		that.stats.Evictions++
+	}
+}
+
+func (that *CachedGetter) remove(elem *list.Element) {
+	that.lru.Remove(elem)
+	delete(that.entries, elem.Value.(*cachedEntry).name)
+}
+
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+type CachedGetterBuilder struct {
+	getter *CachedGetter
+}
+
+func NewCachedGetterBuilder() *CachedGetterBuilder {
+	return &CachedGetterBuilder{
+		getter: &CachedGetter{
+			entries: make(map[string]*list.Element),
+			lru:     list.New(),
+			calls:   make(map[string]*cachedCall),
+		},
+	}
+}
+
+func (that *CachedGetterBuilder) WithGetter(getter Getter) *CachedGetterBuilder {
+	that.getter.getter = getter
+	return that
+}
+
+// WithTTL sets the same TTL for all names.
+func (that *CachedGetterBuilder) WithTTL(ttl time.Duration) *CachedGetterBuilder {
+	that.getter.ttl = func(string) time.Duration {
+		return ttl
+	}
+	return that
+}
+
+// WithTTLFunc sets TTL per name. Zero TTL disables caching of the name.
+func (that *CachedGetterBuilder) WithTTLFunc(ttl func(name string) time.Duration) *CachedGetterBuilder {
+	that.getter.ttl = ttl
+	return that
+}
+
+// WithNegativeTTL sets TTL of missing values. Zero TTL disables negative caching.
+func (that *CachedGetterBuilder) WithNegativeTTL(ttl time.Duration) *CachedGetterBuilder {
+	that.getter.negativeTTL = ttl
+	return that
+}
+
+// WithMaxSize sets limit of cached values. Zero means unlimited cache.
+func (that *CachedGetterBuilder) WithMaxSize(size int) *CachedGetterBuilder {
+	that.getter.maxSize = size
+	return that
+}
+
+func (that *CachedGetterBuilder) Build() (*CachedGetter, error) {
+	if err := that.checkRequiredFields(); err != nil {
+		return nil, err
+	}
+
+	return that.getter, nil
+}
+
+func (that *CachedGetterBuilder) checkRequiredFields() error {
+	if that.getter.getter == nil {
+		return ErrGetterRequired
+	}
+
+	if that.getter.ttl == nil {
+		return ErrTTLRequired
+	}
+
+	return nil
+}
+
+var (
+	ErrGetterRequired = errors.New("getter is required")
+	ErrTTLRequired    = errors.New("ttl is required")
+	ErrGetterPanicked = errors.New("getter panicked")
+)
@@ -0,0 +1,309 @@
+package access
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/adverax/metacrm.kernel/containers/maps"
+	"github.com/adverax/metacrm.kernel/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type countingGetter struct {
+	Getter
+	mx    sync.Mutex
+	calls map[string]int
+}
+
+func newCountingGetter(getter Getter) *countingGetter {
+	return &countingGetter{Getter: getter, calls: make(map[string]int)}
+}
+
+func (that *countingGetter) GetProperty(ctx context.Context, name string) (interface{}, error) {
+	that.mx.Lock()
+	that.calls[name]++
+	that.mx.Unlock()
+	return that.Getter.GetProperty(ctx, name)
+}
+
+func (that *countingGetter) Calls(name string) int {
+	that.mx.Lock()
+	defer that.mx.Unlock()
+	return that.calls[name]
+}
+
+func TestCachedGetter(t *testing.T) {
+	ctx := context.Background()
+	data := maps.Map{"a": 1, "b": 2}
+	source := newCountingGetter(data)
+
+	cache, err := NewCachedGetterBuilder().
+		WithGetter(source).
+		WithTTL(time.Hour).
+		WithNegativeTTL(time.Hour).
+		Build()
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		value, err := cache.GetProperty(ctx, "a")
+		require.NoError(t, err)
+		assert.Equal(t, 1, value)
+
+		_, err = cache.GetProperty(ctx, "missing")
+		assert.ErrorIs(t, err, types.ErrNoMatch)
+	}
+	assert.Equal(t, 1, source.Calls("a"))
+	assert.Equal(t, 1, source.Calls("missing"))
+	assert.Equal(t, CacheStats{Hits: 4, Misses: 2, Size: 2}, cache.Stats())
+
+	data["a"] = 10
+	cache.Invalidate("a")
+	value, err := cache.GetProperty(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, 10, value)
+	assert.Equal(t, 2, source.Calls("a"))
+
+	cache.InvalidateAll()
+	assert.Equal(t, 0, cache.Stats().Size)
+}
+
+func TestCachedGetter_TTL(t *testing.T) {
+	ctx := context.Background()
+	source := newCountingGetter(maps.Map{"fast": 1, "slow": 2, "live": 3})
+
+	cache, err := NewCachedGetterBuilder().
+		WithGetter(source).
+		WithTTLFunc(func(name string) time.Duration {
+			switch name {
+			case "fast":
+				return time.Millisecond
+			case "live":
+				return 0
+			}
+			return time.Hour
+		}).
+		Build()
+	require.NoError(t, err)
+
+	for _, name := range []string{"fast", "slow", "live"} {
+		_, err := cache.GetProperty(ctx, name)
+		require.NoError(t, err)
+	}
+	time.Sleep(5 * time.Millisecond)
+	for _, name := range []string{"fast", "slow", "live"} {
+		_, err := cache.GetProperty(ctx, name)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, 2, source.Calls("fast"))
+	assert.Equal(t, 1, source.Calls("slow"))
+	assert.Equal(t, 2, source.Calls("live"))
+}
+
+func TestCachedGetter_Errors(t *testing.T) {
+	ctx := context.Background()
+	failure := errors.New("failure")
+	var calls int32
+	source := GetterFunc(func(ctx context.Context, name string) (interface{}, error) {
+		atomic.AddInt32(&calls, 1)
+		if name == "missing" {
+			return nil, types.ErrNoMatch
+		}
+		return nil, failure
+	})
+
+	cache, err := NewCachedGetterBuilder().
+		WithGetter(source).
+		WithTTL(time.Hour).
+		Build()
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		_, err := cache.GetProperty(ctx, "broken")
+		assert.ErrorIs(t, err, failure)
+		_, err = cache.GetProperty(ctx, "missing")
+		assert.ErrorIs(t, err, types.ErrNoMatch)
+	}
+	assert.Equal(t, int32(4), atomic.LoadInt32(&calls))
+}
+
+func TestCachedGetter_Eviction(t *testing.T) {
+	ctx := context.Background()
+	source := newCountingGetter(maps.Map{"a": 1, "b": 2, "c": 3})
+
+	cache, err := NewCachedGetterBuilder().
+		WithGetter(source).
+		WithTTL(time.Hour).
+		WithMaxSize(2).
+		Build()
+	require.NoError(t, err)
+
+	for _, name := range []string{"a", "b", "a", "c", "a", "b"} {
+		_, err := cache.GetProperty(ctx, name)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, 1, source.Calls("a"))
+	assert.Equal(t, 2, source.Calls("b"))
+	assert.Equal(t, 1, source.Calls("c"))
+	assert.Equal(t, CacheStats{Hits: 2, Misses: 4, Evictions: 2, Size: 2}, cache.Stats())
+}
+
+func TestCachedGetter_Singleflight(t *testing.T) {
+	ctx := context.Background()
+	release := make(chan struct{})
+	var calls int32
+	source := GetterFunc(func(ctx context.Context, name string) (interface{}, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return "value", nil
+	})
+
+	cache, err := NewCachedGetterBuilder().
+		WithGetter(source).
+		WithTTL(time.Hour).
+		Build()
+	require.NoError(t, err)
+
+	const workers = 10
+	var wg sync.WaitGroup
+	values := make([]interface{}, workers)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			values[i], _ = cache.GetProperty(ctx, "key")
+		}(i)
+	}
+
+	require.Eventually(t, func() bool {
+		return cache.Stats().Misses == workers
+	}, time.Second, time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	for _, value := range values {
+		assert.Equal(t, "value", value)
+	}
+}
+
+func TestCachedGetter_InvalidateInFlight(t *testing.T) {
+	ctx := context.Background()
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	var version int32
+	source := GetterFunc(func(ctx context.Context, name string) (interface{}, error) {
+		value := atomic.LoadInt32(&version)
+		started <- struct{}{}
+		<-release
+		return value, nil
+	})
+
+	cache, err := NewCachedGetterBuilder().
+		WithGetter(source).
+		WithTTL(time.Hour).
+		Build()
+	require.NoError(t, err)
+
+	done := make(chan interface{})
+	go func() {
+		value, _ := cache.GetProperty(ctx, "key")
+		done <- value
+	}()
+
+	<-started
+	atomic.StoreInt32(&version, 1)
+	cache.Invalidate("key")
+	close(release)
+	assert.Equal(t, int32(0), <-done)
+
+	// stale value is not cached
+	value, err := cache.GetProperty(ctx, "key")
+	<-started
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), value)
+}
+
+func TestCachedGetter_Panic(t *testing.T) {
+	ctx := context.Background()
+	fail := true
+	source := GetterFunc(func(ctx context.Context, name string) (interface{}, error) {
+		if fail {
+			panic("failure")
+		}
+		return "value", nil
+	})
+
+	cache, err := NewCachedGetterBuilder().
+		WithGetter(source).
+		WithTTL(time.Hour).
+		Build()
+	require.NoError(t, err)
+
+	assert.Panics(t, func() {
+		_, _ = cache.GetProperty(ctx, "key")
+	})
+
+	fail = false
+	value, err := cache.GetProperty(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, "value", value)
+}
+
+func TestCachedGetter_LeaderCanceled(t *testing.T) {
+	release := make(chan struct{})
+	source := GetterFunc(func(ctx context.Context, name string) (interface{}, error) {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-release:
+			return "value", nil
+		}
+	})
+
+	cache, err := NewCachedGetterBuilder().
+		WithGetter(source).
+		WithTTL(time.Hour).
+		Build()
+	require.NoError(t, err)
+
+	leaderCtx, cancel := context.WithCancel(context.Background())
+	leader := make(chan error)
+	go func() {
+		_, err := cache.GetProperty(leaderCtx, "key")
+		leader <- err
+	}()
+	require.Eventually(t, func() bool {
+		return cache.Stats().Misses == 1
+	}, time.Second, time.Millisecond)
+
+	waiter := make(chan interface{})
+	go func() {
+		value, _ := cache.GetProperty(context.Background(), "key")
+		waiter <- value
+	}()
+	require.Eventually(t, func() bool {
+		return cache.Stats().Misses == 2
+	}, time.Second, time.Millisecond)
+
+	cancel()
+	assert.ErrorIs(t, <-leader, context.Canceled)
+	close(release)
+	assert.Equal(t, "value", <-waiter)
+	assert.Equal(t, int64(2), cache.Stats().Misses)
+	assert.Equal(t, int64(0), cache.Stats().Hits)
+}
+
+func TestCachedGetterBuilder(t *testing.T) {
+	_, err := NewCachedGetterBuilder().WithTTL(time.Second).Build()
+	assert.ErrorIs(t, err, ErrGetterRequired)
+
+	_, err = NewCachedGetterBuilder().WithGetter(maps.Map{}).Build()
+	assert.ErrorIs(t, err, ErrTTLRequired)
+}
@@ -0,0 +1,276 @@
+package access
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/adverax/metacrm.kernel/access/paths"
+	"github.com/adverax/metacrm.kernel/types"
+)
+
+// Event describes change of property. Name is normalized (see NormalizeName).
+type Event struct {
+	Name string
+	Old  interface{}
+	New  interface{}
+}
+
+// Observer receives changes. Changes of transaction are received as one batch.
+type Observer func(ctx context.Context, events []Event)
+
+// Observable is ReaderWriterEx, that publishes changes of properties.
+// Writes, that don't change value, are not published.
+type Observable struct {
+	Reader
+	Writer
+	rw   ReaderWriterEx
+	mx   sync.RWMutex
+	subs map[int]*subscription
+	next int
+}
+
+type subscription struct {
+	prefix   string
+	observer Observer
+}
+
+// NewObservable is constructor for build Observable, based on the GetterSetter.
+// GetterSetter without transactions is wrapped by TxReaderWriter.
+func NewObservable(gs GetterSetter) *Observable {
+	rw, ok := gs.(ReaderWriterEx)
+	if !ok {
+		rw = NewTxReaderWriter(gs)
+	}
+
+	o := &Observable{
+		rw:   rw,
+		subs: make(map[int]*subscription),
+	}
+	o.Reader = &reader{Getter: o}
+	o.Writer = &writer{Setter: o}
+	return o
+}
+
+// Subscribe registers observer of properties with the prefix (empty prefix means all properties).
+// Prefix matches whole segments of names: "db" matches "db" and "db.host", but not "dbx".
+// Returns function for unsubscribe.
+func (that *Observable) Subscribe(prefix string, observer Observer) (unsubscribe func()) {
+	that.mx.Lock()
+	defer that.mx.Unlock()
+
+	id := that.next
+	that.next++
+	that.subs[id] = &subscription{prefix: NormalizeName(prefix), observer: observer}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			that.mx.Lock()
+			defer that.mx.Unlock()
+
+			delete(that.subs, id)
+		})
+	}
+}
+
+func (that *Observable) GetProperty(
+	ctx context.Context,
+	name string,
+) (interface{}, error) {
+	return that.rw.GetProperty(ctx, name)
+}
+
+func (that *Observable) SetProperty(
+	ctx context.Context,
+	name string,
+	value interface{},
+) error {
+	var events []Event
+	err := that.rw.Transaction(ctx, func(ctx context.Context, rw ReaderWriter) error {
+		rec := newRecorder(rw)
+		err := rec.SetProperty(ctx, name, value)
+		events = rec.Events()
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	that.publish(ctx, events)
+	return nil
+}
+
+// Transaction runs action in transaction of the underlying ReaderWriterEx.
+// Changes are published as one batch after commit.
+func (that *Observable) Transaction(
+	ctx context.Context,
+	action func(ctx context.Context, rw ReaderWriter) error,
+) error {
+	var events []Event
+	err := that.rw.Transaction(ctx, func(ctx context.Context, rw ReaderWriter) error {
+		rec := newRecorder(rw)
+		if err := action(ctx, rec); err != nil {
+			return err
+		}
+		events = rec.Events()
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	that.publish(ctx, events)
+	return nil
+}
+
+func (that *Observable) publish(ctx context.Context, events []Event) {
+	if len(events) == 0 {
+		return
+	}
+
+	that.mx.RLock()
+	ids := make([]int, 0, len(that.subs))
+	for id := range that.subs {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	subs := make([]*subscription, len(ids))
+	for i, id := range ids {
+		subs[i] = that.subs[id]
+	}
+	that.mx.RUnlock()
+
+	for _, sub := range subs {
+		var matched []Event
+		for _, event := range events {
+			if sub.matches(event.Name) {
+				matched = append(matched, event)
+			}
+		}
+		if len(matched) != 0 {
+			sub.observer(ctx, matched)
+		}
+	}
+}
+
+func (that *subscription) matches(name string) bool {
+	return that.prefix == "" || name == that.prefix || strings.HasPrefix(name, that.prefix+".")
+}
+
+// NormalizeName converts path expression into dot separated keys ("$.servers[0].host" is "servers.0.host"),
+// so the same property has the same name in events. Other names are returned as is.
+func NormalizeName(name string) string {
+	if !strings.HasPrefix(name, "$") {
+		return name
+	}
+
+	path, err := paths.Compile(name)
+	if err != nil || path.HasWildcard() {
+		return name
+	}
+
+	keys := make([]string, len(path.Segments()))
+	for i, segment := range path.Segments() {
+		if segment.Kind == paths.SegmentIndex {
+			keys[i] = strconv.Itoa(segment.Index)
+		} else {
+			keys[i] = segment.Key
+		}
+	}
+	return strings.Join(keys, ".")
+}
+
+// recorder collects changes of transaction.
+type recorder struct {
+	Reader
+	Writer
+	rw     ReaderWriter
+	events map[string]*Event
+	order  []string
+}
+
+func newRecorder(rw ReaderWriter) *recorder {
+	rec := &recorder{
+		rw:     rw,
+		events: make(map[string]*Event),
+	}
+	rec.Reader = &reader{Getter: rec}
+	rec.Writer = &writer{Setter: rec}
+	return rec
+}
+
+func (that *recorder) GetProperty(
+	ctx context.Context,
+	name string,
+) (interface{}, error) {
+	return that.rw.GetProperty(ctx, name)
+}
+
+func (that *recorder) SetProperty(
+	ctx context.Context,
+	name string,
+	value interface{},
+) error {
+	old, err := that.rw.GetProperty(ctx, name)
+	if err != nil && !errors.Is(err, types.ErrNoMatch) {
+		return err
+	}
+
+	if err := that.rw.SetProperty(ctx, name, value); err != nil {
+		return err
+	}
+
+	that.record(name, old, value)
+	return nil
+}
+
+// Transaction starts savepoint, if underlying ReaderWriter supports it.
+func (that *recorder) Transaction(
+	ctx context.Context,
+	action func(ctx context.Context, rw ReaderWriter) error,
+) error {
+	ex, ok := that.rw.(ReaderWriterEx)
+	if !ok {
+		return action(ctx, that)
+	}
+
+	return ex.Transaction(ctx, func(ctx context.Context, rw ReaderWriter) error {
+		rec := newRecorder(rw)
+		if err := action(ctx, rec); err != nil {
+			return err
+		}
+		for _, name := range rec.order {
+			event := rec.events[name]
+			that.record(name, event.Old, event.New)
+		}
+		return nil
+	})
+}
+
+func (that *recorder) record(name string, old, value interface{}) {
+	name = NormalizeName(name)
+	if event, ok := that.events[name]; ok {
+		event.New = value
+		return
+	}
+
+	that.events[name] = &Event{Name: name, Old: old, New: value}
+	that.order = append(that.order, name)
+}
+
+// Events returns changes in order of the first write. Unchanged values are skipped.
+func (that *recorder) Events() []Event {
+	events := make([]Event, 0, len(that.order))
+	for _, name := range that.order {
+		event := that.events[name]
+		if !reflect.DeepEqual(event.Old, event.New) {
+			events = append(events, *event)
+		}
+	}
+	return events
+}
@@ -0,0 +1,92 @@
+package access
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/adverax/metacrm.kernel/containers/maps"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestObservable(t *testing.T) {
+	ctx := context.Background()
+	data := maps.Map{"features.debug": false}
+	o := NewObservable(data)
+
+	var all, features [][]Event
+	unsubscribe := o.Subscribe("", func(ctx context.Context, events []Event) {
+		all = append(all, events)
+	})
+	o.Subscribe("features", func(ctx context.Context, events []Event) {
+		features = append(features, events)
+	})
+
+	require.NoError(t, o.SetBoolean(ctx, "features.debug", true))
+	require.NoError(t, o.SetString(ctx, "name", "My App"))
+	require.NoError(t, o.SetString(ctx, "name", "My App"))
+
+	assert.Equal(t, [][]Event{
+		{{Name: "features.debug", Old: false, New: true}},
+		{{Name: "name", Old: nil, New: "My App"}},
+	}, all)
+	assert.Equal(t, [][]Event{
+		{{Name: "features.debug", Old: false, New: true}},
+	}, features)
+
+	unsubscribe()
+	all, features = nil, nil
+	failure := errors.New("failure")
+
+	err := o.Transaction(ctx, func(ctx context.Context, rw ReaderWriter) error {
+		require.NoError(t, rw.SetProperty(ctx, "features.beta", true))
+		require.NoError(t, rw.SetProperty(ctx, "features.debug", false))
+		require.NoError(t, rw.SetProperty(ctx, "features.beta", false))
+
+		err := rw.(ReaderWriterEx).Transaction(ctx, func(ctx context.Context, sp ReaderWriter) error {
+			_ = sp.SetProperty(ctx, "features.alpha", true)
+			return failure
+		})
+		assert.ErrorIs(t, err, failure)
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.Nil(t, all)
+	assert.Equal(t, [][]Event{{
+		{Name: "features.beta", Old: nil, New: false},
+		{Name: "features.debug", Old: true, New: false},
+	}}, features)
+
+	features = nil
+	err = o.Transaction(ctx, func(ctx context.Context, rw ReaderWriter) error {
+		_ = rw.SetProperty(ctx, "features.debug", true)
+		return failure
+	})
+	assert.ErrorIs(t, err, failure)
+	assert.Nil(t, features)
+	assert.Equal(t, false, data["features.debug"])
+}
+
+func TestObservable_Names(t *testing.T) {
+	ctx := context.Background()
+	o := NewObservable(maps.Map{})
+
+	var limit []Event
+	o.Subscribe("limit", func(ctx context.Context, events []Event) {
+		limit = append(limit, events...)
+	})
+
+	require.NoError(t, o.SetProperty(ctx, "$.limit", 10))
+	require.NoError(t, o.SetProperty(ctx, "limitX", 20))
+
+	assert.Equal(t, []Event{{Name: "limit", Old: nil, New: 10}}, limit)
+}
+
+func TestNormalizeName(t *testing.T) {
+	assert.Equal(t, "limit", NormalizeName("limit"))
+	assert.Equal(t, "limit", NormalizeName("$.limit"))
+	assert.Equal(t, "servers.0.host", NormalizeName("$.servers[0].host"))
+	assert.Equal(t, "$.servers[*]", NormalizeName("$.servers[*]"))
+}
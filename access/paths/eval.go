@@ -0,0 +1,339 @@
+package paths
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/adverax/metacrm.kernel/types"
+	"github.com/adverax/metacrm.kernel/types/convert"
+)
+
+// Get compiles expression and selects value of data.
+func Get(data interface{}, expr string) (interface{}, error) {
+	path, err := Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+	return path.Get(data)
+}
+
+// Set compiles expression and changes value of data.
+func Set(data interface{}, expr string, value interface{}) error {
+	path, err := Compile(expr)
+	if err != nil {
+		return err
+	}
+	return path.Set(data, value)
+}
+
+// Get selects value of data (maps, lists, structs and pointers to them).
+// Path with wildcard returns list of all found values.
+// Returns types.ErrNoMatch, if value is not found.
+func (that *Path) Get(data interface{}) (interface{}, error) {
+	if that.wildcard {
+		var res []interface{}
+		collect(reflect.ValueOf(data), that.segments, &res)
+		return res, nil
+	}
+
+	v := reflect.ValueOf(data)
+	for _, segment := range that.segments {
+		var ok bool
+		v, ok = child(v, segment)
+		if !ok {
+			return nil, types.ErrNoMatch
+		}
+	}
+
+	return valueOf(v), nil
+}
+
+// Set changes value of data. Missing items of maps are created,
+// list can be extended by index, that equals to its length.
+// Data must be map or pointer. Value is converted into type of destination.
+func (that *Path) Set(data interface{}, value interface{}) error {
+	if len(that.segments) == 0 {
+		return fmt.Errorf("%w: empty path", ErrNotSettable)
+	}
+
+	return setValue(reflect.ValueOf(data), that.segments, value)
+}
+
+func collect(v reflect.Value, segments []Segment, res *[]interface{}) {
+	if len(segments) == 0 {
+		*res = append(*res, valueOf(v))
+		return
+	}
+
+	segment := segments[0]
+	if segment.Kind != SegmentWildcard {
+		if item, ok := child(v, segment); ok {
+			collect(item, segments[1:], res)
+		}
+		return
+	}
+
+	v = indirect(v)
+	switch v.Kind() {
+	case reflect.Map:
+		keys := v.MapKeys()
+		sortKeys(keys)
+		for _, key := range keys {
+			collect(v.MapIndex(key), segments[1:], res)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			collect(v.Index(i), segments[1:], res)
+		}
+	case reflect.Struct:
+		for _, index := range fieldsOf(v.Type()).indexes {
+			collect(v.FieldByIndex(index), segments[1:], res)
+		}
+	}
+}
+
+func child(v reflect.Value, segment Segment) (reflect.Value, bool) {
+	v = indirect(v)
+	if !v.IsValid() {
+		return reflect.Value{}, false
+	}
+
+	switch v.Kind() {
+	case reflect.Map:
+		key, ok := mapKeyOf(v.Type(), keyOf(segment))
+		if !ok {
+			return reflect.Value{}, false
+		}
+		item := v.MapIndex(key)
+		return item, item.IsValid()
+	case reflect.Slice, reflect.Array:
+		index, ok := indexOf(segment)
+		if !ok || index >= v.Len() {
+			return reflect.Value{}, false
+		}
+		return v.Index(index), true
+	case reflect.Struct:
+		if segment.Kind != SegmentKey {
+			return reflect.Value{}, false
+		}
+		index, ok := fieldsOf(v.Type()).names[segment.Key]
+		if !ok {
+			return reflect.Value{}, false
+		}
+		return v.FieldByIndex(index), true
+	}
+
+	return reflect.Value{}, false
+}
+
+func setValue(v reflect.Value, segments []Segment, value interface{}) error {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			if len(segments) == 0 && value == nil {
+				return nil
+			}
+			if !v.CanSet() {
+				return ErrNotSettable
+			}
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return setValue(v.Elem(), segments, value)
+	case reflect.Interface:
+		if len(segments) == 0 || v.IsNil() {
+			break
+		}
+		if e := v.Elem(); e.Kind() == reflect.Map || e.Kind() == reflect.Ptr {
+			// References are changed in place
+			return setValue(e, segments, value)
+		}
+		// Value inside interface is not addressable, so it is changed through copy
+		item := reflect.New(v.Elem().Type()).Elem()
+		item.Set(v.Elem())
+		if err := setValue(item, segments, value); err != nil {
+			return err
+		}
+		if !v.CanSet() {
+			return ErrNotSettable
+		}
+		v.Set(item)
+		return nil
+	}
+
+	if len(segments) == 0 {
+		return assign(v, value)
+	}
+
+	segment, rest := segments[0], segments[1:]
+	switch v.Kind() {
+	case reflect.Map:
+		return setMapItem(v, segment, rest, value)
+	case reflect.Slice, reflect.Array:
+		if segment.Kind == SegmentWildcard {
+			for i := 0; i < v.Len(); i++ {
+				if err := setValue(v.Index(i), rest, value); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		index, ok := indexOf(segment)
+		if !ok || index > v.Len() || (index == v.Len() && v.Kind() == reflect.Array) {
+			return types.ErrNoMatch
+		}
+		if index == v.Len() {
+			if !v.CanSet() {
+				return ErrNotSettable
+			}
+			v.Set(reflect.Append(v, reflect.Zero(v.Type().Elem())))
+		}
+		return setValue(v.Index(index), rest, value)
+	case reflect.Struct:
+		if segment.Kind == SegmentWildcard {
+			for _, index := range fieldsOf(v.Type()).indexes {
+				if err := setValue(v.FieldByIndex(index), rest, value); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		if segment.Kind != SegmentKey {
+			return types.ErrNoMatch
+		}
+		index, ok := fieldsOf(v.Type()).names[segment.Key]
+		if !ok {
+			return types.ErrNoMatch
+		}
+		return setValue(v.FieldByIndex(index), rest, value)
+	}
+
+	return types.ErrNoMatch
+}
+
+func setMapItem(v reflect.Value, segment Segment, rest []Segment, value interface{}) error {
+	if v.IsNil() {
+		if !v.CanSet() {
+			return ErrNotSettable
+		}
+		v.Set(reflect.MakeMap(v.Type()))
+	}
+
+	if segment.Kind == SegmentWildcard {
+		for _, key := range v.MapKeys() {
+			if err := setMapValue(v, key, rest, value); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	key, ok := mapKeyOf(v.Type(), keyOf(segment))
+	if !ok {
+		return types.ErrNoMatch
+	}
+
+	return setMapValue(v, key, rest, value)
+}
+
+func setMapValue(v reflect.Value, key reflect.Value, rest []Segment, value interface{}) error {
+	// Items of map are not addressable, so they are changed through copy
+	item := reflect.New(v.Type().Elem()).Elem()
+	if old := v.MapIndex(key); old.IsValid() {
+		item.Set(old)
+	} else if len(rest) != 0 && item.Kind() == reflect.Interface && v.Type().AssignableTo(item.Type()) {
+		// Missing nested map has type of the parent map
+		item.Set(reflect.MakeMap(v.Type()))
+	}
+
+	if err := setValue(item, rest, value); err != nil {
+		return err
+	}
+
+	v.SetMapIndex(key, item)
+	return nil
+}
+
+func assign(v reflect.Value, value interface{}) error {
+	if !v.CanSet() {
+		return ErrNotSettable
+	}
+
+	if value == nil {
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+
+	if tv := reflect.ValueOf(value); tv.Type().AssignableTo(v.Type()) {
+		v.Set(tv)
+		return nil
+	}
+
+	// Numbers are converted into strings by ConvertAssign (reflect converts them into runes)
+	if v.Kind() != reflect.String {
+		if tv, ok := convert.To(value, v.Type()); ok {
+			v.Set(tv)
+			return nil
+		}
+	}
+
+	if err := convert.ConvertAssign(v.Addr().Interface(), value); err != nil {
+		return fmt.Errorf("%w: %v into %s: %w", ErrWrongType, value, v.Type(), err)
+	}
+
+	return nil
+}
+
+func indirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Interface || v.Kind() == reflect.Ptr) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+func valueOf(v reflect.Value) interface{} {
+	v = indirect(v)
+	if !v.IsValid() {
+		return nil
+	}
+	return v.Interface()
+}
+
+func keyOf(segment Segment) string {
+	if segment.Kind == SegmentIndex {
+		return strconv.Itoa(segment.Index)
+	}
+	return segment.Key
+}
+
+func indexOf(segment Segment) (int, bool) {
+	switch segment.Kind {
+	case SegmentIndex:
+		return segment.Index, true
+	case SegmentKey:
+		index, err := strconv.Atoi(segment.Key)
+		return index, err == nil && index >= 0
+	}
+	return 0, false
+}
+
+func mapKeyOf(tp reflect.Type, key string) (reflect.Value, bool) {
+	if tp.Key().Kind() == reflect.String {
+		return reflect.ValueOf(key).Convert(tp.Key()), true
+	}
+
+	k := reflect.New(tp.Key()).Elem()
+	if err := convert.ConvertAssign(k.Addr().Interface(), key); err != nil {
+		return reflect.Value{}, false
+	}
+	return k, true
+}
+
+var (
+	ErrNotSettable = errors.New("value is not settable")
+	ErrWrongType   = errors.New("wrong type")
+)
@@ -0,0 +1,131 @@
+package paths
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Field is field of struct with its name. Index is sequence of indexes for reflect.Value.FieldByIndex.
+type Field struct {
+	reflect.StructField
+	Name string
+}
+
+// FieldsOf returns fields of struct type, that are named by tags (see FieldName).
+// Fields of embedded structs without name are flattened (as in json),
+// fields without exported data (for example sync.RWMutex) are skipped.
+func FieldsOf(tp reflect.Type, tags ...string) []Field {
+	var res []Field
+	for i := 0; i < tp.NumField(); i++ {
+		field := tp.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct && !hasTag(field, tags) {
+			for _, f := range FieldsOf(field.Type, tags...) {
+				f.Index = append([]int{i}, f.Index...)
+				res = append(res, f)
+			}
+			continue
+		}
+
+		if !field.IsExported() || !hasData(field.Type) {
+			continue
+		}
+
+		name, ok := FieldName(field, tags...)
+		if !ok {
+			continue
+		}
+
+		res = append(res, Field{StructField: field, Name: name})
+	}
+	return res
+}
+
+// FieldName returns name of field, that is taken from the first found tag
+// (lower case name of field by default). Returns false for excluded fields (tag "-").
+func FieldName(field reflect.StructField, tags ...string) (string, bool) {
+	for _, tag := range tags {
+		raw, ok := field.Tag.Lookup(tag)
+		if !ok {
+			continue
+		}
+		if raw == "-" {
+			return "", false
+		}
+		if name, _, _ := strings.Cut(raw, ","); name != "" && !strings.Contains(name, "=") {
+			return name, true
+		}
+	}
+
+	return strings.ToLower(field.Name), true
+}
+
+func hasTag(field reflect.StructField, tags []string) bool {
+	for _, tag := range tags {
+		if field.Tag.Get(tag) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// hasData checks, that struct type has exported fields (directly or in embedded structs).
+func hasData(tp reflect.Type) bool {
+	if tp.Kind() != reflect.Struct || tp == timeType {
+		return true
+	}
+
+	for i := 0; i < tp.NumField(); i++ {
+		field := tp.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			if hasData(field.Type) {
+				return true
+			}
+			continue
+		}
+		if field.IsExported() {
+			return true
+		}
+	}
+	return false
+}
+
+// fields is cached metadata of struct type for paths.
+// Names are taken from tags `config` and `json`.
+type fields struct {
+	names   map[string][]int
+	indexes [][]int
+}
+
+func fieldsOf(tp reflect.Type) *fields {
+	if meta, ok := structs.Load(tp); ok {
+		return meta.(*fields)
+	}
+
+	list := FieldsOf(tp, "config", "json")
+	meta := &fields{
+		names:   make(map[string][]int, len(list)),
+		indexes: make([][]int, 0, len(list)),
+	}
+	for _, field := range list {
+		meta.names[field.Name] = field.Index
+		meta.indexes = append(meta.indexes, field.Index)
+	}
+
+	actual, _ := structs.LoadOrStore(tp, meta)
+	return actual.(*fields)
+}
+
+func sortKeys(keys []reflect.Value) {
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+	})
+}
+
+var (
+	structs  sync.Map
+	timeType = reflect.TypeOf(time.Time{})
+)
@@ -0,0 +1,258 @@
+package paths
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+type SegmentKind int
+
+const (
+	// SegmentKey selects item of map or field of struct (".name", "['a.b']").
+	// Numeric key also selects item of list (".0").
+	SegmentKey SegmentKind = iota
+	// SegmentIndex selects item of list ("[0]").
+	SegmentIndex
+	// SegmentWildcard selects all items (".*", "[*]").
+	SegmentWildcard
+)
+
+type Segment struct {
+	Kind  SegmentKind
+	Key   string
+	Index int
+}
+
+// Path is compiled path expression:
+//
+//	$.servers[0].host
+//	$.users[*].email
+//	$['a.b'].c
+//
+// Prefix "$." may be omitted: "address.host".
+type Path struct {
+	expr     string
+	segments []Segment
+	wildcard bool
+}
+
+// Compile compiles expression. Compiled paths are cached.
+func Compile(expr string) (*Path, error) {
+	if path, ok := cache.Load(expr); ok {
+		return path.(*Path), nil
+	}
+
+	path, err := parse(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	if atomic.LoadInt64(&cacheSize) < maxCacheSize {
+		// Concurrent compiles of the same expression are counted once
+		if cached, loaded := cache.LoadOrStore(expr, path); loaded {
+			return cached.(*Path), nil
+		}
+		atomic.AddInt64(&cacheSize, 1)
+	}
+	return path, nil
+}
+
+// MustCompile is like Compile, but panics on error.
+func MustCompile(expr string) *Path {
+	path, err := Compile(expr)
+	if err != nil {
+		panic(err)
+	}
+	return path
+}
+
+// String returns source expression.
+func (that *Path) String() string {
+	return that.expr
+}
+
+// Segments returns segments of path.
+func (that *Path) Segments() []Segment {
+	return that.segments
+}
+
+// Rel returns path relative to the base path.
+// Returns false, if the base path is not prefix of the path.
+func (that *Path) Rel(base *Path) (*Path, bool) {
+	if len(base.segments) > len(that.segments) {
+		return nil, false
+	}
+	for i, segment := range base.segments {
+		if !segment.equal(that.segments[i]) {
+			return nil, false
+		}
+	}
+
+	segments := that.segments[len(base.segments):]
+	rel := &Path{segments: segments}
+	var b strings.Builder
+	b.WriteString("$")
+	for _, segment := range segments {
+		b.WriteString(segment.String())
+		if segment.Kind == SegmentWildcard {
+			rel.wildcard = true
+		}
+	}
+	rel.expr = b.String()
+	return rel, true
+}
+
+// String returns segment in bracket notation.
+func (that Segment) String() string {
+	switch that.Kind {
+	case SegmentIndex:
+		return "[" + strconv.Itoa(that.Index) + "]"
+	case SegmentWildcard:
+		return "[*]"
+	}
+	return "['" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(that.Key) + "']"
+}
+
+func (that Segment) equal(other Segment) bool {
+	if that.Kind == SegmentWildcard || other.Kind == SegmentWildcard {
+		return that.Kind == other.Kind
+	}
+	return keyOf(that) == keyOf(other)
+}
+
+// HasWildcard checks if path can select several values.
+func (that *Path) HasWildcard() bool {
+	return that.wildcard
+}
+
+func parse(expr string) (*Path, error) {
+	p := &parser{src: expr}
+	path := &Path{expr: expr}
+
+	switch {
+	case strings.HasPrefix(expr, "$"):
+		p.pos = 1
+	case expr != "":
+		// Relative path starts with key
+		p.src = "." + expr
+	}
+
+	for !p.eof() {
+		segment, err := p.segment()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q: %w", ErrSyntax, expr, err)
+		}
+		if segment.Kind == SegmentWildcard {
+			path.wildcard = true
+		}
+		path.segments = append(path.segments, segment)
+	}
+
+	return path, nil
+}
+
+type parser struct {
+	src string
+	pos int
+}
+
+func (that *parser) eof() bool {
+	return that.pos >= len(that.src)
+}
+
+func (that *parser) segment() (Segment, error) {
+	switch that.src[that.pos] {
+	case '.':
+		that.pos++
+		if !that.eof() && that.src[that.pos] == '*' {
+			that.pos++
+			return Segment{Kind: SegmentWildcard}, nil
+		}
+		start := that.pos
+		for !that.eof() && that.src[that.pos] != '.' && that.src[that.pos] != '[' {
+			that.pos++
+		}
+		if start == that.pos {
+			return Segment{}, fmt.Errorf("empty key at %d", start)
+		}
+		return Segment{Kind: SegmentKey, Key: that.src[start:that.pos]}, nil
+	case '[':
+		that.pos++
+		segment, err := that.bracket()
+		if err != nil {
+			return Segment{}, err
+		}
+		if that.eof() || that.src[that.pos] != ']' {
+			return Segment{}, fmt.Errorf("expected ']' at %d", that.pos)
+		}
+		that.pos++
+		return segment, nil
+	}
+
+	return Segment{}, fmt.Errorf("unexpected character %q at %d", that.src[that.pos], that.pos)
+}
+
+func (that *parser) bracket() (Segment, error) {
+	if that.eof() {
+		return Segment{}, fmt.Errorf("unexpected end")
+	}
+
+	switch ch := that.src[that.pos]; {
+	case ch == '*':
+		that.pos++
+		return Segment{Kind: SegmentWildcard}, nil
+	case ch == '\'' || ch == '"':
+		key, err := that.quoted(ch)
+		if err != nil {
+			return Segment{}, err
+		}
+		return Segment{Kind: SegmentKey, Key: key}, nil
+	default:
+		start := that.pos
+		for !that.eof() && that.src[that.pos] != ']' {
+			that.pos++
+		}
+		index, err := strconv.Atoi(that.src[start:that.pos])
+		if err != nil || index < 0 {
+			return Segment{}, fmt.Errorf("invalid index %q at %d", that.src[start:that.pos], start)
+		}
+		return Segment{Kind: SegmentIndex, Index: index}, nil
+	}
+}
+
+func (that *parser) quoted(quote byte) (string, error) {
+	start := that.pos
+	that.pos++
+
+	var b strings.Builder
+	for !that.eof() {
+		ch := that.src[that.pos]
+		that.pos++
+		switch {
+		case ch == quote:
+			return b.String(), nil
+		case ch == '\\' && !that.eof():
+			b.WriteByte(that.src[that.pos])
+			that.pos++
+		default:
+			b.WriteByte(ch)
+		}
+	}
+
+	return "", fmt.Errorf("unterminated key at %d", start)
+}
+
+const maxCacheSize = 4096
+
+var (
+	cache     sync.Map
+	cacheSize int64
+)
+
+var (
+	ErrSyntax = errors.New("invalid path")
+)
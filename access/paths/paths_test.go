@@ -0,0 +1,186 @@
+package paths
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/adverax/metacrm.kernel/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompile(t *testing.T) {
+	tests := map[string][]Segment{
+		"$":                 nil,
+		"$.a.b":             {{Kind: SegmentKey, Key: "a"}, {Kind: SegmentKey, Key: "b"}},
+		"a.b":               {{Kind: SegmentKey, Key: "a"}, {Kind: SegmentKey, Key: "b"}},
+		"$.servers[0].host": {{Kind: SegmentKey, Key: "servers"}, {Kind: SegmentIndex, Index: 0}, {Kind: SegmentKey, Key: "host"}},
+		"$.users[*].email":  {{Kind: SegmentKey, Key: "users"}, {Kind: SegmentWildcard}, {Kind: SegmentKey, Key: "email"}},
+		"$.users.*":         {{Kind: SegmentKey, Key: "users"}, {Kind: SegmentWildcard}},
+		`$['a.b']["c\"d"]`:  {{Kind: SegmentKey, Key: "a.b"}, {Kind: SegmentKey, Key: `c"d`}},
+	}
+
+	for expr, expected := range tests {
+		t.Run(expr, func(t *testing.T) {
+			path, err := Compile(expr)
+			require.NoError(t, err)
+			assert.Equal(t, expected, path.Segments())
+			assert.Equal(t, expr, path.String())
+		})
+	}
+
+	for _, expr := range []string{"$.", "$..a", "$[", "$[x]", "$['a", "$[0", "$a"} {
+		_, err := Compile(expr)
+		assert.ErrorIs(t, err, ErrSyntax, expr)
+	}
+
+	a := MustCompile("$.cached")
+	b := MustCompile("$.cached")
+	assert.Same(t, a, b)
+}
+
+func TestPath_Get(t *testing.T) {
+	type user struct {
+		Email string `json:"email"`
+	}
+
+	data := map[string]interface{}{
+		"name": "My App",
+		"a.b":  1,
+		"servers": []interface{}{
+			map[string]interface{}{"host": "a"},
+			map[string]interface{}{"host": "b"},
+		},
+		"users": []user{{Email: "x@example.com"}, {Email: "y@example.com"}},
+		"limits": map[string]int{
+			"rps": 100,
+		},
+	}
+
+	tests := map[string]interface{}{
+		"$.name":            "My App",
+		"$['a.b']":          1,
+		"$.servers[1].host": "b",
+		"$.servers.0.host":  "a",
+		"$.servers[*].host": []interface{}{"a", "b"},
+		"$.users[*].email":  []interface{}{"x@example.com", "y@example.com"},
+		"$.users[0]":        user{Email: "x@example.com"},
+		"$.limits.rps":      100,
+		"$.unknown[*]":      []interface{}(nil),
+	}
+
+	for expr, expected := range tests {
+		t.Run(expr, func(t *testing.T) {
+			actual, err := Get(data, expr)
+			require.NoError(t, err)
+			assert.Equal(t, expected, actual)
+		})
+	}
+
+	for _, expr := range []string{"$.unknown", "$.servers[2]", "$.name.x", "$.users[0].name"} {
+		_, err := Get(data, expr)
+		assert.ErrorIs(t, err, types.ErrNoMatch, expr)
+	}
+}
+
+func TestPath_Set(t *testing.T) {
+	data := map[string]interface{}{
+		"servers": []interface{}{
+			map[string]interface{}{"host": "a"},
+			map[string]interface{}{"host": "b"},
+		},
+	}
+
+	require.NoError(t, Set(data, "$.address.port", 80))
+	require.NoError(t, Set(data, "$['a.b']", true))
+	require.NoError(t, Set(data, "$.servers[*].port", 8080))
+	require.NoError(t, Set(data, "$.servers[0].host", "c"))
+
+	assert.Equal(t, map[string]interface{}{
+		"address": map[string]interface{}{"port": 80},
+		"a.b":     true,
+		"servers": []interface{}{
+			map[string]interface{}{"host": "c", "port": 8080},
+			map[string]interface{}{"host": "b", "port": 8080},
+		},
+	}, data)
+
+	type server struct {
+		Port int `config:"port"`
+	}
+	config := &struct {
+		Servers []server `config:"servers"`
+	}{}
+	require.NoError(t, Set(config, "$.servers[0].port", "80"))
+	assert.Equal(t, []server{{Port: 80}}, config.Servers)
+
+	err := Set(config, "$.servers[0].port", "http")
+	assert.ErrorIs(t, err, ErrWrongType)
+	err = Set(config, "$.servers[3].port", 1)
+	assert.ErrorIs(t, err, types.ErrNoMatch)
+}
+
+func TestPath_Rel(t *testing.T) {
+	base := MustCompile("$.db")
+
+	rel, ok := MustCompile("$.db.servers[0]['a.b']").Rel(base)
+	require.True(t, ok)
+	assert.Equal(t, `$['servers'][0]['a.b']`, rel.String())
+	value, err := rel.Get(map[string]interface{}{
+		"servers": []interface{}{map[string]interface{}{"a.b": 1}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, value)
+
+	rel, ok = MustCompile("db").Rel(base)
+	require.True(t, ok)
+	assert.Equal(t, "$", rel.String())
+
+	_, ok = MustCompile("$.cache.host").Rel(base)
+	assert.False(t, ok)
+	_, ok = base.Rel(MustCompile("$.db.host"))
+	assert.False(t, ok)
+}
+
+func TestCompile_CacheSize(t *testing.T) {
+	size := cacheSize
+	MustCompile("$.counted.once")
+	MustCompile("$.counted.once")
+	assert.Equal(t, size+1, cacheSize)
+}
+
+type fieldsTestBase struct {
+	sync.RWMutex
+	mx   sync.Mutex
+	Name string `config:"name"`
+}
+
+type fieldsTestConfig struct {
+	fieldsTestBase
+	Port    int            `json:"port"`
+	Address fieldsTestBase `config:"address"`
+	Skipped string         `config:"-"`
+}
+
+func TestFieldsOf(t *testing.T) {
+	var names []string
+	var indexes [][]int
+	for _, field := range FieldsOf(reflect.TypeOf(fieldsTestConfig{}), "config") {
+		names = append(names, field.Name)
+		indexes = append(indexes, field.Index)
+	}
+	assert.Equal(t, []string{"name", "port", "address"}, names)
+	assert.Equal(t, [][]int{{0, 2}, {1}, {2}}, indexes)
+
+	data := &fieldsTestConfig{Port: 80}
+	require.NoError(t, Set(data, "$.name", "app"))
+	assert.Equal(t, "app", data.Name)
+
+	value, err := Get(data, "$.port")
+	require.NoError(t, err)
+	assert.Equal(t, 80, value)
+
+	_, err = Get(data, "$.rwmutex")
+	assert.ErrorIs(t, err, types.ErrNoMatch)
+}
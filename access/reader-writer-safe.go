@@ -175,6 +175,72 @@ func (that *SafeReaderWriter) SetJson(
 	return that.ReaderWriter.SetJson(ctx, name, value)
 }
 
+func (that *SafeReaderWriter) GetTime(
+	ctx context.Context,
+	name string,
+	defVal time.Time,
+) (time.Time, error) {
+	that.RLock()
+	defer that.RUnlock()
+
+	return that.ReaderWriter.GetTime(ctx, name, defVal)
+}
+
+func (that *SafeReaderWriter) GetStrings(
+	ctx context.Context,
+	name string,
+	defVal []string,
+) ([]string, error) {
+	that.RLock()
+	defer that.RUnlock()
+
+	return that.ReaderWriter.GetStrings(ctx, name, defVal)
+}
+
+func (that *SafeReaderWriter) GetMap(
+	ctx context.Context,
+	name string,
+	defVal map[string]interface{},
+) (map[string]interface{}, error) {
+	that.RLock()
+	defer that.RUnlock()
+
+	return that.ReaderWriter.GetMap(ctx, name, defVal)
+}
+
+func (that *SafeReaderWriter) SetTime(
+	ctx context.Context,
+	name string,
+	value time.Time,
+) error {
+	that.Lock()
+	defer that.Unlock()
+
+	return that.ReaderWriter.SetTime(ctx, name, value)
+}
+
+func (that *SafeReaderWriter) SetStrings(
+	ctx context.Context,
+	name string,
+	value []string,
+) error {
+	that.Lock()
+	defer that.Unlock()
+
+	return that.ReaderWriter.SetStrings(ctx, name, value)
+}
+
+func (that *SafeReaderWriter) SetMap(
+	ctx context.Context,
+	name string,
+	value map[string]interface{},
+) error {
+	that.Lock()
+	defer that.Unlock()
+
+	return that.ReaderWriter.SetMap(ctx, name, value)
+}
+
 func NewSafeReaderWriter(rw ReaderWriter) *SafeReaderWriter {
 	return &SafeReaderWriter{
 		ReaderWriter: rw,
@@ -0,0 +1,306 @@
+package access
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/adverax/metacrm.kernel/access/paths"
+	"github.com/adverax/metacrm.kernel/types"
+)
+
+// TxReaderWriter is ReaderWriterEx with rollback semantics.
+// Writes of the transaction are buffered in the overlay, that is visible for reads
+// inside the transaction. The overlay is applied on success and discarded on error.
+// Nested transactions work as savepoints.
+type TxReaderWriter struct {
+	Reader
+	Writer
+	mx sync.RWMutex
+	gs GetterSetter
+}
+
+// NewTxReaderWriter is constructor for build TxReaderWriter, based on the GetterSetter
+func NewTxReaderWriter(gs GetterSetter) *TxReaderWriter {
+	rw := &TxReaderWriter{gs: gs}
+	rw.Reader = &reader{Getter: rw}
+	rw.Writer = &writer{Setter: rw}
+	return rw
+}
+
+func (that *TxReaderWriter) GetProperty(
+	ctx context.Context,
+	name string,
+) (interface{}, error) {
+	that.mx.RLock()
+	defer that.mx.RUnlock()
+
+	return that.gs.GetProperty(ctx, name)
+}
+
+func (that *TxReaderWriter) SetProperty(
+	ctx context.Context,
+	name string,
+	value interface{},
+) error {
+	that.mx.Lock()
+	defer that.mx.Unlock()
+
+	return that.gs.SetProperty(ctx, name, value)
+}
+
+// Transaction runs action exclusively. Changes are committed, if action returns nil.
+// If commit fails, already applied changes are restored. New keys are removed,
+// if GetterSetter implements Deleter, otherwise ErrPartialRollback is returned.
+func (that *TxReaderWriter) Transaction(
+	ctx context.Context,
+	action func(ctx context.Context, rw ReaderWriter) error,
+) error {
+	that.mx.Lock()
+	defer that.mx.Unlock()
+
+	tx := newTxOverlay(that.gs)
+	err := tx.run(ctx, action)
+	if err != nil {
+		return err
+	}
+
+	return that.commit(ctx, tx)
+}
+
+func (that *TxReaderWriter) commit(ctx context.Context, tx *txOverlay) error {
+	type backup struct {
+		name   string
+		value  interface{}
+		exists bool
+	}
+
+	backups := make([]backup, 0, len(tx.order))
+	for _, name := range tx.order {
+		old, err := that.gs.GetProperty(ctx, name)
+		if err != nil && !errors.Is(err, types.ErrNoMatch) {
+			return err
+		}
+		backups = append(backups, backup{name: name, value: old, exists: err == nil})
+	}
+
+	for i, name := range tx.order {
+		err := that.gs.SetProperty(ctx, name, tx.writes[name])
+		if err != nil {
+			partial := false
+			for j := i - 1; j >= 0; j-- {
+				if e := that.restore(ctx, backups[j].name, backups[j].value, backups[j].exists); e != nil {
+					partial = true
+				}
+			}
+			if partial {
+				return fmt.Errorf("%w: %w", err, ErrPartialRollback)
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (that *TxReaderWriter) restore(ctx context.Context, name string, value interface{}, exists bool) error {
+	if exists {
+		return that.gs.SetProperty(ctx, name, value)
+	}
+
+	if deleter, ok := that.gs.(Deleter); ok {
+		return deleter.DeleteProperty(ctx, name)
+	}
+
+	return ErrPartialRollback
+}
+
+// txOverlay is view of the transaction.
+type txOverlay struct {
+	Reader
+	Writer
+	parent Getter
+	writes map[string]interface{}
+	order  []string
+	done   bool
+}
+
+func newTxOverlay(parent Getter) *txOverlay {
+	tx := &txOverlay{
+		parent: parent,
+		writes: make(map[string]interface{}),
+	}
+	tx.Reader = &reader{Getter: tx}
+	tx.Writer = &writer{Setter: tx}
+	return tx
+}
+
+func (that *txOverlay) GetProperty(
+	ctx context.Context,
+	name string,
+) (interface{}, error) {
+	if that.done {
+		return nil, ErrTxDone
+	}
+
+	path, ok := pathOf(name)
+	if !ok {
+		if value, ok := that.writes[name]; ok {
+			return value, nil
+		}
+		return that.parent.GetProperty(ctx, name)
+	}
+
+	// Value of path is combined from the parent value and the writes of parent and child paths
+	value, err := that.parent.GetProperty(ctx, name)
+	if err != nil && !errors.Is(err, types.ErrNoMatch) {
+		return nil, err
+	}
+	found := err == nil
+	copied := false
+	for _, written := range that.order {
+		writtenPath, ok := pathOf(written)
+		if !ok {
+			continue
+		}
+
+		if rel, ok := path.Rel(writtenPath); ok {
+			value, err = rel.Get(that.writes[written])
+			found, copied = err == nil, false
+			continue
+		}
+
+		if rel, ok := writtenPath.Rel(path); ok {
+			if !found {
+				value = make(map[string]interface{})
+			} else if !copied {
+				value = copyValue(value)
+			}
+			found, copied = true, true
+			if err := rel.Set(value, that.writes[written]); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if !found {
+		return nil, types.ErrNoMatch
+	}
+	return value, nil
+}
+
+func (that *txOverlay) SetProperty(
+	_ context.Context,
+	name string,
+	value interface{},
+) error {
+	if that.done {
+		return ErrTxDone
+	}
+
+	// Order of writes is order of the last changes
+	if _, ok := that.writes[name]; ok {
+		for i, item := range that.order {
+			if item == name {
+				that.order = append(that.order[:i], that.order[i+1:]...)
+				break
+			}
+		}
+	}
+	that.order = append(that.order, name)
+	that.writes[name] = value
+	return nil
+}
+
+// Transaction starts savepoint: changes of the nested action are merged into
+// the enclosing transaction on success and discarded on error.
+func (that *txOverlay) Transaction(
+	ctx context.Context,
+	action func(ctx context.Context, rw ReaderWriter) error,
+) error {
+	if that.done {
+		return ErrTxDone
+	}
+
+	tx := newTxOverlay(that)
+	err := tx.run(ctx, action)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range tx.order {
+		_ = that.SetProperty(ctx, name, tx.writes[name])
+	}
+	return nil
+}
+
+func (that *txOverlay) run(
+	ctx context.Context,
+	action func(ctx context.Context, rw ReaderWriter) error,
+) error {
+	defer func() {
+		that.done = true
+	}()
+
+	return action(ctx, that)
+}
+
+// pathOf compiles name, that is path expression (see paths.Compile).
+func pathOf(name string) (*paths.Path, bool) {
+	if !strings.HasPrefix(name, "$") {
+		return nil, false
+	}
+
+	path, err := paths.Compile(name)
+	if err != nil || path.HasWildcard() {
+		return nil, false
+	}
+	return path, true
+}
+
+// copyValue makes deep copy of maps and slices, so writes of the overlay don't change the parent.
+func copyValue(value interface{}) interface{} {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Map:
+		if v.IsNil() {
+			return value
+		}
+		res := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			res.SetMapIndex(iter.Key(), copyItem(iter.Value(), v.Type().Elem()))
+		}
+		return res.Interface()
+	case reflect.Slice:
+		if v.IsNil() {
+			return value
+		}
+		res := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			res.Index(i).Set(copyItem(v.Index(i), v.Type().Elem()))
+		}
+		return res.Interface()
+	}
+	return value
+}
+
+func copyItem(item reflect.Value, tp reflect.Type) reflect.Value {
+	if item.Kind() == reflect.Interface && item.IsNil() {
+		return item
+	}
+
+	res := reflect.ValueOf(copyValue(item.Interface()))
+	if !res.IsValid() {
+		return reflect.Zero(tp)
+	}
+	return res
+}
+
+var (
+	ErrTxDone          = errors.New("transaction is already finished")
+	ErrPartialRollback = errors.New("rollback is partial")
+)
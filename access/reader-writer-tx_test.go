@@ -0,0 +1,174 @@
+package access
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/adverax/metacrm.kernel/containers/maps"
+	"github.com/adverax/metacrm.kernel/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxReaderWriter_Transaction(t *testing.T) {
+	ctx := context.Background()
+	data := maps.Map{"a": int64(1)}
+	rw := NewTxReaderWriter(data)
+	failure := errors.New("failure")
+
+	err := rw.Transaction(ctx, func(ctx context.Context, tx ReaderWriter) error {
+		require.NoError(t, tx.SetInteger(ctx, "a", 2))
+		require.NoError(t, tx.SetString(ctx, "b", "x"))
+
+		a, err := tx.GetInteger(ctx, "a", 0)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), a)
+		assert.Equal(t, int64(1), data["a"])
+
+		return failure
+	})
+	assert.ErrorIs(t, err, failure)
+	assert.Equal(t, maps.Map{"a": int64(1)}, data)
+
+	var leaked ReaderWriter
+	err = rw.Transaction(ctx, func(ctx context.Context, tx ReaderWriter) error {
+		leaked = tx
+		require.NoError(t, tx.SetInteger(ctx, "a", 2))
+
+		// savepoint is discarded
+		err := tx.(ReaderWriterEx).Transaction(ctx, func(ctx context.Context, sp ReaderWriter) error {
+			require.NoError(t, sp.SetInteger(ctx, "a", 3))
+			a, err := sp.GetInteger(ctx, "a", 0)
+			require.NoError(t, err)
+			assert.Equal(t, int64(3), a)
+			return failure
+		})
+		assert.ErrorIs(t, err, failure)
+
+		// savepoint is merged
+		return tx.(ReaderWriterEx).Transaction(ctx, func(ctx context.Context, sp ReaderWriter) error {
+			return sp.SetString(ctx, "b", "y")
+		})
+	})
+	require.NoError(t, err)
+	assert.Equal(t, maps.Map{"a": int64(2), "b": "y"}, data)
+
+	_, err = leaked.GetProperty(ctx, "a")
+	assert.ErrorIs(t, err, ErrTxDone)
+}
+
+func TestTxReaderWriter_CommitFailure(t *testing.T) {
+	ctx := context.Background()
+	data := maps.Map{"a": 1, "b": 2}
+	failure := errors.New("failure")
+	gs := &struct {
+		GetterFunc
+		SetterFunc
+	}{
+		GetterFunc: data.GetProperty,
+		SetterFunc: func(ctx context.Context, name string, value interface{}) error {
+			if name == "c" {
+				return failure
+			}
+			return data.SetProperty(ctx, name, value)
+		},
+	}
+
+	rw := NewTxReaderWriter(gs)
+	err := rw.Transaction(ctx, func(ctx context.Context, tx ReaderWriter) error {
+		_ = tx.SetProperty(ctx, "a", 10)
+		_ = tx.SetProperty(ctx, "b", 20)
+		return tx.SetProperty(ctx, "c", 30)
+	})
+	assert.ErrorIs(t, err, failure)
+	assert.Equal(t, maps.Map{"a": 1, "b": 2}, data)
+}
+
+func TestTxReaderWriter_Paths(t *testing.T) {
+	ctx := context.Background()
+	data := maps.Map{
+		"db": map[string]interface{}{"host": "localhost", "port": 5432},
+	}
+	rw := NewTxReaderWriter(data)
+
+	err := rw.Transaction(ctx, func(ctx context.Context, tx ReaderWriter) error {
+		require.NoError(t, tx.SetProperty(ctx, "$.db.host", "example.com"))
+		require.NoError(t, tx.SetProperty(ctx, "$.cache.ttl", "5s"))
+
+		db, err := tx.GetProperty(ctx, "$.db")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"host": "example.com", "port": 5432}, db)
+
+		cache, err := tx.GetProperty(ctx, "$.cache")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"ttl": "5s"}, cache)
+
+		require.NoError(t, tx.SetProperty(ctx, "$.db", map[string]interface{}{"host": "db"}))
+		host, err := tx.GetProperty(ctx, "$.db.host")
+		require.NoError(t, err)
+		assert.Equal(t, "db", host)
+		_, err = tx.GetProperty(ctx, "$.db.port")
+		assert.ErrorIs(t, err, types.ErrNoMatch)
+
+		// parent is not changed before commit
+		assert.Equal(t, "localhost", data["db"].(map[string]interface{})["host"])
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, maps.Map{
+		"db":    map[string]interface{}{"host": "db"},
+		"cache": maps.Map{"ttl": "5s"},
+	}, data)
+}
+
+type txTestDeleter struct {
+	maps.Map
+	failure error
+}
+
+func (that *txTestDeleter) SetProperty(ctx context.Context, name string, value interface{}) error {
+	if name == "fail" {
+		return that.failure
+	}
+	return that.Map.SetProperty(ctx, name, value)
+}
+
+func (that *txTestDeleter) DeleteProperty(_ context.Context, name string) error {
+	delete(that.Map, name)
+	return nil
+}
+
+func TestTxReaderWriter_RollbackNewKey(t *testing.T) {
+	ctx := context.Background()
+	failure := errors.New("failure")
+	action := func(ctx context.Context, tx ReaderWriter) error {
+		_ = tx.SetProperty(ctx, "a", 10)
+		_ = tx.SetProperty(ctx, "new", 20)
+		return tx.SetProperty(ctx, "fail", 30)
+	}
+
+	deleter := &txTestDeleter{Map: maps.Map{"a": 1}, failure: failure}
+	err := NewTxReaderWriter(deleter).Transaction(ctx, action)
+	assert.ErrorIs(t, err, failure)
+	assert.NotErrorIs(t, err, ErrPartialRollback)
+	assert.Equal(t, maps.Map{"a": 1}, deleter.Map)
+
+	data := maps.Map{"a": 1}
+	gs := &struct {
+		GetterFunc
+		SetterFunc
+	}{
+		GetterFunc: data.GetProperty,
+		SetterFunc: func(ctx context.Context, name string, value interface{}) error {
+			if name == "fail" {
+				return failure
+			}
+			return data.SetProperty(ctx, name, value)
+		},
+	}
+	err = NewTxReaderWriter(gs).Transaction(ctx, action)
+	assert.ErrorIs(t, err, failure)
+	assert.ErrorIs(t, err, ErrPartialRollback)
+	assert.Equal(t, 1, data["a"])
+}
@@ -60,6 +60,30 @@ func (that *reader) GetJson(
 	return types.Json.Get(ctx, that, name, defVal)
 }
 
+func (that *reader) GetTime(
+	ctx context.Context,
+	name string,
+	defVal time.Time,
+) (res time.Time, err error) {
+	return types.Time.Get(ctx, that, name, defVal)
+}
+
+func (that *reader) GetStrings(
+	ctx context.Context,
+	name string,
+	defVal []string,
+) (res []string, err error) {
+	return types.Strings.Get(ctx, that, name, defVal)
+}
+
+func (that *reader) GetMap(
+	ctx context.Context,
+	name string,
+	defVal map[string]interface{},
+) (res map[string]interface{}, err error) {
+	return types.Map.Get(ctx, that, name, defVal)
+}
+
 // NewReader is constructor for build Reader, based on the props
 func NewReader(getter Getter) ReaderGetter {
 	return &reader{
@@ -0,0 +1,78 @@
+package access
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/adverax/metacrm.kernel/access/paths"
+)
+
+// StructAccessor exposes struct as GetterSetter.
+// Names are path expressions (see paths.Compile): "$.address.host", "$.servers[0].host".
+// Names of fields are taken from tags `config` and `json` (lower case name of field by default),
+// fields of embedded structs are flattened (see paths.FieldsOf).
+// Accessor is safe for concurrent use, if the struct is changed only through the accessor.
+type StructAccessor struct {
+	mx  sync.RWMutex
+	ptr interface{}
+}
+
+// NewStructAccessor is constructor for build StructAccessor, based on the pointer to struct
+func NewStructAccessor(ptr interface{}) (*StructAccessor, error) {
+	v := reflect.ValueOf(ptr)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: expected pointer to struct, got %T", ErrWrongStruct, ptr)
+	}
+
+	return &StructAccessor{ptr: ptr}, nil
+}
+
+// NewStructReaderWriter is constructor for build ReaderWriter, based on the pointer to struct
+func NewStructReaderWriter(ptr interface{}) (ReaderWriter, error) {
+	accessor, err := NewStructAccessor(ptr)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewReaderWriter(accessor), nil
+}
+
+func (that *StructAccessor) GetProperty(
+	_ context.Context,
+	name string,
+) (interface{}, error) {
+	path, err := paths.Compile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	that.mx.RLock()
+	defer that.mx.RUnlock()
+
+	return path.Get(that.ptr)
+}
+
+func (that *StructAccessor) SetProperty(
+	_ context.Context,
+	name string,
+	value interface{},
+) error {
+	path, err := paths.Compile(name)
+	if err != nil {
+		return err
+	}
+
+	that.mx.Lock()
+	defer that.mx.Unlock()
+
+	return path.Set(that.ptr, value)
+}
+
+var (
+	ErrWrongStruct = errors.New("wrong struct")
+	ErrNotSettable = paths.ErrNotSettable
+	ErrWrongType   = paths.ErrWrongType
+)
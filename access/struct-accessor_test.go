@@ -0,0 +1,100 @@
+package access
+
+import (
+	"context"
+	"testing"
+
+	"github.com/adverax/metacrm.kernel/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type accessorTestServer struct {
+	Host string `json:"host"`
+	Port int    `config:"port"`
+}
+
+type accessorTestConfig struct {
+	Name    string                        `config:"name"`
+	Address *accessorTestServer           `config:"address"`
+	Servers []accessorTestServer          `json:"servers"`
+	Limits  map[string]int                `config:"limits"`
+	Extra   map[string]accessorTestServer `config:"extra"`
+	Secret  string                        `config:"-"`
+	Debug   bool
+}
+
+func TestStructAccessor_GetProperty(t *testing.T) {
+	ctx := context.Background()
+	config := &accessorTestConfig{
+		Name:    "My App",
+		Address: &accessorTestServer{Host: "localhost", Port: 80},
+		Servers: []accessorTestServer{{Host: "a"}, {Host: "b"}},
+		Limits:  map[string]int{"rps": 100},
+		Debug:   true,
+	}
+
+	rw, err := NewStructReaderWriter(config)
+	require.NoError(t, err)
+
+	tests := map[string]interface{}{
+		"name":              "My App",
+		"$.name":            "My App",
+		"$.address.host":    "localhost",
+		"$.address.port":    80,
+		"$.servers.1.host":  "b",
+		"$.servers[0].host": "a",
+		"$.limits.rps":      100,
+		"$.debug":           true,
+		"$.address":         accessorTestServer{Host: "localhost", Port: 80},
+	}
+
+	for name, expected := range tests {
+		t.Run(name, func(t *testing.T) {
+			actual, err := rw.GetProperty(ctx, name)
+			require.NoError(t, err)
+			assert.Equal(t, expected, actual)
+		})
+	}
+
+	for _, name := range []string{"$.secret", "$.servers.2.host", "$.limits.rpm", "$.unknown"} {
+		_, err := rw.GetProperty(ctx, name)
+		assert.ErrorIs(t, err, types.ErrNoMatch, name)
+	}
+
+	port, err := rw.GetInteger(ctx, "$.address.port", 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(80), port)
+}
+
+func TestStructAccessor_SetProperty(t *testing.T) {
+	ctx := context.Background()
+	config := &accessorTestConfig{}
+
+	rw, err := NewStructReaderWriter(config)
+	require.NoError(t, err)
+
+	require.NoError(t, rw.SetProperty(ctx, "$.name", 42))
+	require.NoError(t, rw.SetProperty(ctx, "$.address.port", "8080"))
+	require.NoError(t, rw.SetProperty(ctx, "$.servers.0.host", "a"))
+	require.NoError(t, rw.SetProperty(ctx, "$.limits.rps", int64(100)))
+	require.NoError(t, rw.SetProperty(ctx, "$.extra.db.port", 5432))
+	require.NoError(t, rw.SetBoolean(ctx, "$.debug", true))
+
+	assert.Equal(t, &accessorTestConfig{
+		Name:    "42",
+		Address: &accessorTestServer{Port: 8080},
+		Servers: []accessorTestServer{{Host: "a"}},
+		Limits:  map[string]int{"rps": 100},
+		Extra:   map[string]accessorTestServer{"db": {Port: 5432}},
+		Debug:   true,
+	}, config)
+
+	err = rw.SetProperty(ctx, "$.address.port", "http")
+	assert.ErrorIs(t, err, ErrWrongType)
+	err = rw.SetProperty(ctx, "$.servers.5.host", "x")
+	assert.ErrorIs(t, err, types.ErrNoMatch)
+
+	_, err = NewStructAccessor(*config)
+	assert.ErrorIs(t, err, ErrWrongStruct)
+}
@@ -59,6 +59,30 @@ func (that *writer) SetJson(
 	return that.SetProperty(ctx, name, string(value))
 }
 
+func (that *writer) SetTime(
+	ctx context.Context,
+	name string,
+	value time.Time,
+) error {
+	return that.SetProperty(ctx, name, value)
+}
+
+func (that *writer) SetStrings(
+	ctx context.Context,
+	name string,
+	value []string,
+) error {
+	return that.SetProperty(ctx, name, value)
+}
+
+func (that *writer) SetMap(
+	ctx context.Context,
+	name string,
+	value map[string]interface{},
+) error {
+	return that.SetProperty(ctx, name, value)
+}
+
 // NewWriter is constructor for build Writer, based on the setter
 func NewWriter(setter Setter) Writer {
 	return &writer{
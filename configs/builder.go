@@ -10,6 +10,7 @@ func NewBuilder() *Builder {
 	return &Builder{
 		loader: &Loader{
 			converter: DefaultConverter,
+			merger:    DefaultMerger,
 		},
 	}
 }
@@ -19,11 +20,28 @@ func (that *Builder) WithSource(sources ...Source) *Builder {
 	return that
 }
 
+// WithFiles declares files, that sources are based on. Used by Watcher.
+func (that *Builder) WithFiles(files ...string) *Builder {
+	that.loader.files = append(that.loader.files, files...)
+	return that
+}
+
 func (that *Builder) WithConverter(converter Converter) *Builder {
 	that.loader.converter = converter
 	return that
 }
 
+func (that *Builder) WithMerger(merger Merger) *Builder {
+	that.loader.merger = merger
+	return that
+}
+
+// WithSchema enables validation of sources by schema (see SchemaOf).
+func (that *Builder) WithSchema(schema *Schema) *Builder {
+	that.loader.schema = schema
+	return that
+}
+
 func (that *Builder) WithDistinct(distinct bool) *Builder {
 	that.loader.distinct = distinct
 	return that
@@ -46,9 +64,14 @@ func (that *Builder) checkRequiredFields() error {
 		return ErrFieldConverterIsRequired
 	}
 
+	if that.loader.merger == nil {
+		return ErrFieldMergerIsRequired
+	}
+
 	return nil
 }
 
 var (
 	ErrFieldSourcesIsRequired = fmt.Errorf("Field sources is required")
+	ErrFieldMergerIsRequired  = fmt.Errorf("Field merger is required")
 )
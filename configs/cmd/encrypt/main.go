@@ -0,0 +1,74 @@
+// Command encrypt encrypts values for config files (see configs.DecryptedSource).
+//
+// Usage:
+//
+//	encrypt -generate > secret.key
+//	encrypt -key-file secret.key "db password"
+//	echo "db password" | MYAPP_SECRET_KEY=... encrypt -key-env MYAPP_SECRET_KEY
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/adverax/metacrm.kernel/configs"
+)
+
+func main() {
+	keyFile := flag.String("key-file", "", "file with base64 or hex encoded key")
+	keyEnv := flag.String("key-env", "", "environment variable with base64 or hex encoded key")
+	generate := flag.Bool("generate", false, "generate new key")
+	flag.Parse()
+
+	if err := run(*keyFile, *keyEnv, *generate, flag.Args()); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(keyFile, keyEnv string, generate bool, values []string) error {
+	if generate {
+		key, err := configs.GenerateKey()
+		if err != nil {
+			return err
+		}
+		fmt.Println(configs.EncodeKey(key))
+		return nil
+	}
+
+	var cipher *configs.Cipher
+	var err error
+	switch {
+	case keyFile != "":
+		cipher, err = configs.NewCipherFromFile(keyFile)
+	case keyEnv != "":
+		cipher, err = configs.NewCipherFromEnv(keyEnv)
+	default:
+		return fmt.Errorf("key is required: use -key-file or -key-env")
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(values) == 0 {
+		scanner := bufio.NewScanner(os.Stdin)
+		for scanner.Scan() {
+			values = append(values, scanner.Text())
+		}
+		if err := scanner.Err(); err != nil {
+			return err
+		}
+	}
+
+	for _, value := range values {
+		encrypted, err := cipher.Encrypt(value)
+		if err != nil {
+			return err
+		}
+		fmt.Println(encrypted)
+	}
+
+	return nil
+}
@@ -0,0 +1,6 @@
+// Package configs loads config structs from sources (files, environment, flags, remote services).
+//
+// String values of sources, decorated by InterpolatedSource, may contain references ${VAR}.
+// Inside such values "$$" is escaped dollar sign. Values without references are never changed,
+// so existing values with "$$" (passwords, DSN) are kept as is.
+package configs
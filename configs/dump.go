@@ -0,0 +1,256 @@
+package configs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/adverax/metacrm.kernel/enums"
+	"github.com/adverax/metacrm.kernel/log"
+	"gopkg.in/yaml.v3"
+)
+
+// DumpFormat is output format of Dump.
+type DumpFormat int
+
+func (that DumpFormat) String() string {
+	return DumpFormats.DecodeOrDefault(that, "unknown")
+}
+
+const (
+	DumpYaml DumpFormat = iota
+	DumpJson
+	DumpEnv
+)
+
+var DumpFormats = enums.New[DumpFormat](
+	map[DumpFormat]string{
+		DumpYaml: "yaml",
+		DumpJson: "json",
+		DumpEnv:  "env",
+	},
+)
+
+// Secret maskers, keyed by value of tag `secret`.
+// Tag without value uses log.MaskAny.
+var SecretMaskers = map[string]log.Masker{
+	"":       log.MaskAny,
+	"any":    log.MaskAny,
+	"email":  log.MaskEmail,
+	"phone":  log.MaskPhone,
+	"idcard": log.MaskIDCard,
+}
+
+// Dump renders config with names of tags. Values of secret fields are masked:
+//
+//	Password string `config:"password,secret"`
+//	Email    string `config:"email,secret=email"`
+func Dump(config interface{}, format DumpFormat) ([]byte, error) {
+	data, err := Export(config)
+	if err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case DumpYaml:
+		return yaml.Marshal(data)
+	case DumpJson:
+		return json.MarshalIndent(data, "", "  ")
+	case DumpEnv:
+		return dumpEnv(data), nil
+	}
+
+	return nil, fmt.Errorf("%w: %v", ErrUnknownFormat, format)
+}
+
+// Export converts config into map with names of tags. Values of secret fields are masked.
+// Config is read locked, if it implements Config.
+func Export(config interface{}) (map[string]interface{}, error) {
+	if c, ok := config.(Config); ok {
+		c.RLock()
+		defer c.RUnlock()
+	}
+
+	value, err := exportValue(reflect.ValueOf(config))
+	if err != nil {
+		return nil, err
+	}
+
+	data, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("config must be struct, got %T", config)
+	}
+
+	return data, nil
+}
+
+func exportValue(value reflect.Value) (interface{}, error) {
+	if !value.IsValid() {
+		return nil, nil
+	}
+
+	switch value.Type() {
+	case durationType:
+		return time.Duration(value.Int()).String(), nil
+	case timeType:
+		return value.Interface().(time.Time).Format(time.RFC3339Nano), nil
+	}
+
+	switch value.Kind() {
+	case reflect.Ptr:
+		if value.IsNil() {
+			return nil, nil
+		}
+		return exportValue(value.Elem())
+	case reflect.Interface:
+		if value.IsNil() {
+			return nil, nil
+		}
+		return exportInterface(value.Elem())
+	case reflect.Struct:
+		return exportStruct(value)
+	case reflect.Slice, reflect.Array:
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			return string(value.Bytes()), nil
+		}
+		if value.Kind() == reflect.Slice && value.IsNil() {
+			return nil, nil
+		}
+		res := make([]interface{}, value.Len())
+		for i := range res {
+			item, err := exportValue(value.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			res[i] = item
+		}
+		return res, nil
+	case reflect.Map:
+		if value.IsNil() {
+			return nil, nil
+		}
+		res := make(map[string]interface{}, value.Len())
+		iter := value.MapRange()
+		for iter.Next() {
+			item, err := exportValue(iter.Value())
+			if err != nil {
+				return nil, err
+			}
+			res[fmt.Sprint(iter.Key().Interface())] = item
+		}
+		return res, nil
+	}
+
+	return value.Interface(), nil
+}
+
+// exportInterface resolves typed values (Integer, String, ...) by method Get.
+func exportInterface(value reflect.Value) (interface{}, error) {
+	method := value.MethodByName("Get")
+	if method.IsValid() && method.Type().NumIn() == 1 && method.Type().NumOut() == 2 &&
+		method.Type().In(0) == contextType && method.Type().Out(1) == errorType {
+		out := method.Call([]reflect.Value{reflect.ValueOf(context.Background())})
+		if err, _ := out[1].Interface().(error); err != nil {
+			return nil, err
+		}
+		return exportValue(out[0])
+	}
+
+	return exportValue(value)
+}
+
+func exportStruct(value reflect.Value) (interface{}, error) {
+	fields := fieldsOf(value.Type())
+	res := make(map[string]interface{}, len(fields))
+	for _, field := range fields {
+		name := field.Name
+		item, err := exportValue(value.FieldByIndex(field.Index))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+
+		if kind, ok := ParseTags(field.Tag.Get("config"))["secret"]; ok && item != nil {
+			masker, ok := SecretMaskers[kind]
+			if !ok {
+				return nil, fmt.Errorf("%s: unknown secret masker %q", name, kind)
+			}
+			item = masker(item)
+		}
+
+		res[name] = item
+	}
+	return res, nil
+}
+
+// dumpEnv renders data as dotenv file: nested keys are joined by "_" in upper case.
+func dumpEnv(data map[string]interface{}) []byte {
+	lines := make(map[string]string)
+	flattenEnv(lines, "", data)
+
+	keys := make([]string, 0, len(lines))
+	for key := range lines {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	for _, key := range keys {
+		buf.WriteString(key)
+		buf.WriteString("=")
+		buf.WriteString(quoteEnv(lines[key]))
+		buf.WriteString("\n")
+	}
+	return buf.Bytes()
+}
+
+func flattenEnv(lines map[string]string, prefix string, value interface{}) {
+	join := func(key string) string {
+		key = strings.ToUpper(key)
+		if prefix == "" {
+			return key
+		}
+		return prefix + "_" + key
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			flattenEnv(lines, join(key), item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			flattenEnv(lines, join(strconv.Itoa(i)), item)
+		}
+	case nil:
+		lines[prefix] = ""
+	default:
+		lines[prefix] = fmt.Sprint(v)
+	}
+}
+
+func quoteEnv(value string) string {
+	plain := true
+	for _, c := range value {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || strings.ContainsRune("_-.:/@+,", c)) {
+			plain = false
+			break
+		}
+	}
+	if plain {
+		return value
+	}
+
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, `$`, `\$`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+	return `"` + replacer.Replace(value) + `"`
+}
+
+var (
+	contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+)
@@ -0,0 +1,118 @@
+package configs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type dumpTestDatabase struct {
+	User     string `config:"user"`
+	Password string `config:"password,secret"`
+	Email    string `config:"email,secret=email"`
+}
+
+type dumpTestConfig struct {
+	Name     string           `config:"name"`
+	Timeout  time.Duration    `config:"timeout"`
+	Database dumpTestDatabase `config:"database"`
+	Tags     []string         `config:"tags"`
+	Internal string           `config:"-"`
+}
+
+func newDumpTestConfig() *dumpTestConfig {
+	return &dumpTestConfig{
+		Name:    "My App",
+		Timeout: 5 * time.Second,
+		Database: dumpTestDatabase{
+			User:     "admin",
+			Password: "s3cr3t",
+			Email:    "admin@example.com",
+		},
+		Tags:     []string{"a", "b"},
+		Internal: "hidden",
+	}
+}
+
+func TestDump(t *testing.T) {
+	tests := map[DumpFormat]string{
+		DumpYaml: `database:
+    email: ad****@example.com
+    password: '****'
+    user: admin
+name: My App
+tags:
+    - a
+    - b
+timeout: 5s
+`,
+		DumpJson: `{
+  "database": {
+    "email": "ad****@example.com",
+    "password": "****",
+    "user": "admin"
+  },
+  "name": "My App",
+  "tags": [
+    "a",
+    "b"
+  ],
+  "timeout": "5s"
+}`,
+		DumpEnv: `DATABASE_EMAIL="ad****@example.com"
+DATABASE_PASSWORD="****"
+DATABASE_USER=admin
+NAME="My App"
+TAGS_0=a
+TAGS_1=b
+TIMEOUT=5s
+`,
+	}
+
+	for format, expected := range tests {
+		t.Run(format.String(), func(t *testing.T) {
+			actual, err := Dump(newDumpTestConfig(), format)
+			require.NoError(t, err)
+			assert.Equal(t, expected, string(actual))
+		})
+	}
+}
+
+func TestExport_Embedded(t *testing.T) {
+	type config struct {
+		BaseConfig
+		dumpTestDatabase
+		Name string `config:"name"`
+	}
+
+	cfg := &config{
+		dumpTestDatabase: dumpTestDatabase{User: "admin", Password: "s3cr3t"},
+		Name:             "My App",
+	}
+
+	data, err := Export(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"user":     "admin",
+		"password": "****",
+		"email":    "****",
+		"name":     "My App",
+	}, data)
+
+	cfg.Lock()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = Dump(cfg, DumpYaml)
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("dump must wait for unlock")
+	case <-time.After(10 * time.Millisecond):
+	}
+	cfg.Unlock()
+	<-done
+}
@@ -0,0 +1,134 @@
+package configs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/adverax/metacrm.kernel/access"
+	"github.com/adverax/metacrm.kernel/types"
+	"github.com/adverax/metacrm.kernel/types/convert"
+)
+
+// Dynamic is config value, that is read live from the getter (for example table of settings)
+// and cached for ttl. Static value (loaded from files by Let) is used, when getter has no value
+// or fails. Dynamic[bool] implements Boolean, Dynamic[int64] implements Integer and so on:
+//
+//	type Config struct {
+//		Debug configs.Boolean `config:"debug"`
+//	}
+//
+//	config := &Config{
+//		Debug: configs.NewDynamic[bool](settings, "features.debug", time.Minute),
+//	}
+type Dynamic[T any] struct {
+	mx           sync.Mutex
+	getter       access.Getter
+	key          string
+	ttl          time.Duration
+	static       T
+	value        T
+	expires      time.Time
+	errorHandler func(err error)
+}
+
+func NewDynamic[T any](getter access.Getter, key string, ttl time.Duration) *Dynamic[T] {
+	return &Dynamic[T]{
+		getter: getter,
+		key:    key,
+		ttl:    ttl,
+	}
+}
+
+// WithDefault sets static value, that is used until config is loaded.
+func (that *Dynamic[T]) WithDefault(value T) *Dynamic[T] {
+	that.static = value
+	return that
+}
+
+// WithErrorHandler sets handler of getter errors. Errors are not returned by Get,
+// because static value is used instead.
+func (that *Dynamic[T]) WithErrorHandler(handler func(err error)) *Dynamic[T] {
+	that.errorHandler = handler
+	return that
+}
+
+// Get returns cached value or reads it from the getter.
+func (that *Dynamic[T]) Get(ctx context.Context) (T, error) {
+	that.mx.Lock()
+	defer that.mx.Unlock()
+
+	now := time.Now()
+	if now.Before(that.expires) {
+		return that.value, nil
+	}
+
+	that.value = that.fetch(ctx)
+	that.expires = now.Add(that.ttl)
+	return that.value, nil
+}
+
+// Let sets static value (see Letter).
+func (that *Dynamic[T]) Let(ctx context.Context, value T) error {
+	that.mx.Lock()
+	defer that.mx.Unlock()
+
+	that.static = value
+	that.expires = time.Time{}
+	return nil
+}
+
+// Invalidate drops cached value, so the next Get reads the getter.
+func (that *Dynamic[T]) Invalidate() {
+	that.mx.Lock()
+	defer that.mx.Unlock()
+
+	that.expires = time.Time{}
+}
+
+// Watch invalidates cached value, when the key is changed in the observable.
+func (that *Dynamic[T]) Watch(observable *access.Observable) (unsubscribe func()) {
+	key := access.NormalizeName(that.key)
+	return observable.Subscribe(key, func(ctx context.Context, events []access.Event) {
+		for _, event := range events {
+			if event.Name == key {
+				that.Invalidate()
+				return
+			}
+		}
+	})
+}
+
+func (that *Dynamic[T]) fetch(ctx context.Context) T {
+	raw, err := that.getter.GetProperty(ctx, that.key)
+	if err != nil {
+		if !errors.Is(err, types.ErrNoMatch) {
+			that.error(fmt.Errorf("error get dynamic value %q: %w", that.key, err))
+		}
+		return that.static
+	}
+
+	if raw == nil {
+		return that.static
+	}
+
+	value, ok := convert.As[T](raw)
+	if !ok {
+		that.error(fmt.Errorf("%w: value %v of key %q", ErrWrongDynamicValue, raw, that.key))
+		return that.static
+	}
+
+	return value
+}
+
+func (that *Dynamic[T]) error(err error) {
+	if that.errorHandler != nil {
+		that.errorHandler(err)
+	}
+}
+
+var (
+	ErrWrongDynamicValue = errors.New("wrong dynamic value")
+)
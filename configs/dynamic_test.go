@@ -0,0 +1,113 @@
+package configs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/adverax/metacrm.kernel/access"
+	"github.com/adverax/metacrm.kernel/access/fetchers/maps/maps"
+	containers "github.com/adverax/metacrm.kernel/containers/maps"
+	"github.com/adverax/metacrm.kernel/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type dynamicTestConfig struct {
+	Debug   Boolean  `config:"debug"`
+	Limit   Integer  `config:"limit"`
+	Timeout Duration `config:"timeout"`
+}
+
+func TestDynamic(t *testing.T) {
+	ctx := context.Background()
+	settings := map[string]interface{}{}
+	var fail error
+	calls := 0
+	getter := access.GetterFunc(func(ctx context.Context, name string) (interface{}, error) {
+		calls++
+		if fail != nil {
+			return nil, fail
+		}
+		if v, ok := settings[name]; ok {
+			return v, nil
+		}
+		return nil, types.ErrNoMatch
+	})
+
+	var errs []error
+	config := &dynamicTestConfig{
+		Debug:   NewDynamic[bool](getter, "debug", time.Hour).WithErrorHandler(func(err error) { errs = append(errs, err) }),
+		Limit:   NewDynamic[int64](getter, "limit", 0),
+		Timeout: NewDynamic[time.Duration](getter, "timeout", 0),
+	}
+
+	loader, err := NewBuilder().
+		WithSource(maps.Engine{"debug": false, "limit": 10, "timeout": "5s"}).
+		Build()
+	require.NoError(t, err)
+	require.NoError(t, loader.Load(config))
+
+	// static values
+	limit, err := config.Limit.Get(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), limit)
+	timeout, err := config.Timeout.Get(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 5*time.Second, timeout)
+
+	// live values
+	settings["limit"] = "20"
+	limit, err = config.Limit.Get(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(20), limit)
+
+	// cached values
+	debug, err := config.Debug.Get(ctx)
+	require.NoError(t, err)
+	assert.False(t, debug)
+	settings["debug"] = true
+	calls = 0
+	debug, err = config.Debug.Get(ctx)
+	require.NoError(t, err)
+	assert.False(t, debug)
+	assert.Equal(t, 0, calls)
+
+	config.Debug.(*Dynamic[bool]).Invalidate()
+	debug, err = config.Debug.Get(ctx)
+	require.NoError(t, err)
+	assert.True(t, debug)
+
+	// fallback to static value
+	fail = errors.New("connection refused")
+	config.Debug.(*Dynamic[bool]).Invalidate()
+	debug, err = config.Debug.Get(ctx)
+	require.NoError(t, err)
+	assert.False(t, debug)
+	require.Len(t, errs, 1)
+	assert.ErrorIs(t, errs[0], fail)
+}
+
+func TestDynamic_Watch(t *testing.T) {
+	ctx := context.Background()
+	settings := access.NewObservable(containers.Map{"limit": 10})
+
+	limit := NewDynamic[int](settings, "limit", time.Hour)
+	unsubscribe := limit.Watch(settings)
+	defer unsubscribe()
+
+	value, err := limit.Get(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 10, value)
+
+	require.NoError(t, settings.SetProperty(ctx, "limit", 20))
+	value, err = limit.Get(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 20, value)
+
+	require.NoError(t, settings.SetProperty(ctx, "$.limit", 30))
+	value, err = limit.Get(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 30, value)
+}
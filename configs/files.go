@@ -9,46 +9,125 @@ type SourceBuilder func(Fetcher) Source
 
 type FileLoaderBuilder struct {
 	sources   []Source
+	files     []string
 	builder   SourceBuilder
+	formats   *Formats
 	converter Converter
+	merger    Merger
+	schema    *Schema
+	distinct  bool
 	err       error
 }
 
 func NewFileLoaderBuilder() *FileLoaderBuilder {
 	return &FileLoaderBuilder{
+		formats:   NewFormats(),
 		converter: DefaultConverter,
+		merger:    DefaultMerger,
 	}
 }
 
+// WithSourceBuilder forces builder for all files.
+// Without it, format of file is detected by extension.
 func (that *FileLoaderBuilder) WithSourceBuilder(builder SourceBuilder) *FileLoaderBuilder {
 	that.builder = builder
 	return that
 }
 
+// WithFormat registers format for extension locally (in addition to the global formats).
+func (that *FileLoaderBuilder) WithFormat(ext string, builder SourceBuilder) *FileLoaderBuilder {
+	that.formats.Register(ext, builder)
+	return that
+}
+
 func (that *FileLoaderBuilder) WithSource(sources ...Source) *FileLoaderBuilder {
 	that.sources = append(that.sources, sources...)
 	return that
 }
 
 func (that *FileLoaderBuilder) WithFile(file string, mustExists bool) *FileLoaderBuilder {
+	builder, err := that.builderOf(file)
+	if err != nil {
+		that.err = err
+		return that
+	}
+
+	return that.withFile(file, mustExists, builder)
+}
+
+// WithFileFormat adds file with explicit format extension (for example "yaml").
+func (that *FileLoaderBuilder) WithFileFormat(file string, ext string, mustExists bool) *FileLoaderBuilder {
+	builder := that.formats.Get(ext)
+	if builder == nil {
+		builder = formats.Get(ext)
+	}
+	if builder == nil {
+		that.err = fmt.Errorf("%w: %q", ErrUnknownFormat, ext)
+		return that
+	}
+
+	return that.withFile(file, mustExists, builder)
+}
+
+func (that *FileLoaderBuilder) withFile(file string, mustExists bool, builder SourceBuilder) *FileLoaderBuilder {
 	fetcher, err := fileFetchers.NewBuilder().
 		WithFilename(file).
 		WithMustExists(mustExists).
 		Build()
-	if that.err != nil {
+	if err != nil {
 		that.err = err
 		return that
 	}
 
-	that.sources = append(that.sources, that.builder(fetcher))
+	that.sources = append(that.sources, builder(fetcher))
+	that.files = append(that.files, fetcher.Filename())
 	return that
 }
 
+func (that *FileLoaderBuilder) builderOf(file string) (SourceBuilder, error) {
+	if that.builder != nil {
+		return that.builder, nil
+	}
+
+	if builder, err := that.formats.Detect(file); err == nil {
+		return builder, nil
+	}
+
+	return formats.Detect(file)
+}
+
 func (that *FileLoaderBuilder) WithConverter(converter Converter) *FileLoaderBuilder {
 	that.converter = converter
 	return that
 }
 
+// WithProfiles adds base file and its overlays for active profiles.
+// For example, file "config.yaml" with profiles "prod" and "eu" resolves
+// into "config.yaml", "config.prod.yaml" and "config.prod.eu.yaml".
+// Overlays are optional.
+func (that *FileLoaderBuilder) WithProfiles(file string, mustExists bool, profiles ...string) *FileLoaderBuilder {
+	for i, f := range ProfileFiles(file, profiles...) {
+		that.WithFile(f, mustExists && i == 0)
+	}
+	return that
+}
+
+func (that *FileLoaderBuilder) WithMerger(merger Merger) *FileLoaderBuilder {
+	that.merger = merger
+	return that
+}
+
+// WithSchema enables validation of files by schema (see SchemaOf).
+func (that *FileLoaderBuilder) WithSchema(schema *Schema) *FileLoaderBuilder {
+	that.schema = schema
+	return that
+}
+
+func (that *FileLoaderBuilder) WithDistinct(distinct bool) *FileLoaderBuilder {
+	that.distinct = distinct
+	return that
+}
+
 func (that *FileLoaderBuilder) Build() (*Loader, error) {
 	if err := that.checkRequiredFields(); err != nil {
 		return nil, err
@@ -56,7 +135,11 @@ func (that *FileLoaderBuilder) Build() (*Loader, error) {
 
 	return NewBuilder().
 		WithSource(that.sources...).
+		WithFiles(that.files...).
 		WithConverter(that.converter).
+		WithMerger(that.merger).
+		WithSchema(that.schema).
+		WithDistinct(that.distinct).
 		Build()
 }
 
@@ -69,10 +152,6 @@ func (that *FileLoaderBuilder) checkRequiredFields() error {
 		return ErrFieldFilesIsRequired
 	}
 
-	if that.builder == nil {
-		return ErrFieldBuilderIsRequired
-	}
-
 	if that.converter == nil {
 		return ErrFieldConverterIsRequired
 	}
@@ -82,6 +161,5 @@ func (that *FileLoaderBuilder) checkRequiredFields() error {
 
 var (
 	ErrFieldFilesIsRequired     = fmt.Errorf("Files are required")
-	ErrFieldBuilderIsRequired   = fmt.Errorf("Builder is required")
 	ErrFieldConverterIsRequired = fmt.Errorf("Converter is required")
 )
@@ -0,0 +1,284 @@
+package configs
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FlagSource is source of command-line flags, derived from tags of config struct.
+// Nested names are joined by dot: --address.host, --address.port.
+// Only flags, that are set explicitly, are fetched, so the source is usually added last.
+type FlagSource struct {
+	flags  *flag.FlagSet
+	values map[string]*flagValue
+}
+
+// NewFlagSource makes source and parses args (usually os.Args[1:]).
+// Current values of config are shown in help as defaults.
+func NewFlagSource(config interface{}, args []string) (*FlagSource, error) {
+	flags := flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+	source := RegisterFlags(flags, config)
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+	return source, nil
+}
+
+// RegisterFlags defines flags of config in the existing flag set.
+// Flag set must be parsed before fetching the source.
+func RegisterFlags(flags *flag.FlagSet, config interface{}) *FlagSource {
+	source := &FlagSource{
+		flags:  flags,
+		values: make(map[string]*flagValue),
+	}
+	source.define("", reflect.ValueOf(config), make(map[reflect.Type]bool))
+	return source
+}
+
+// FlagSet returns underlying flag set (for example for printing of usage).
+func (that *FlagSource) FlagSet() *flag.FlagSet {
+	return that.flags
+}
+
+// Args returns non-flag arguments.
+func (that *FlagSource) Args() []string {
+	return that.flags.Args()
+}
+
+func (that *FlagSource) Fetch() (map[string]interface{}, error) {
+	data := make(map[string]interface{})
+	that.flags.Visit(func(f *flag.Flag) {
+		value, ok := that.values[f.Name]
+		if !ok {
+			return
+		}
+
+		path := strings.Split(f.Name, ".")
+		node := data
+		for _, key := range path[:len(path)-1] {
+			next, ok := node[key].(map[string]interface{})
+			if !ok {
+				next = make(map[string]interface{})
+				node[key] = next
+			}
+			node = next
+		}
+		node[path[len(path)-1]] = value.get()
+	})
+	return data, nil
+}
+
+func (that *FlagSource) define(prefix string, value reflect.Value, visited map[reflect.Type]bool) {
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			value = reflect.Zero(value.Type().Elem())
+		} else {
+			value = value.Elem()
+		}
+	}
+
+	tp := value.Type()
+	if tp.Kind() != reflect.Struct || visited[tp] {
+		return
+	}
+
+	visited[tp] = true
+	defer delete(visited, tp)
+
+	for _, field := range fieldsOf(tp) {
+		name := field.Name
+		if prefix != "" {
+			name = prefix + "." + name
+		}
+
+		fieldValue := value.FieldByIndex(field.Index)
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Interface {
+			if method, ok := fieldType.MethodByName("Get"); ok && method.Type.NumOut() != 0 {
+				fieldType = method.Type.Out(0)
+			}
+		}
+		for fieldType.Kind() == reflect.Ptr && fieldType.Elem().Kind() != reflect.Struct {
+			fieldType = fieldType.Elem()
+		}
+
+		kind, list := flagKindOf(fieldType)
+		if kind == flagNone {
+			that.define(name, fieldValue, visited)
+			continue
+		}
+
+		doc := field.Tag.Get("doc")
+		def := defaultOf(fieldValue)
+		if list {
+			v := &flagValue{kind: kind, def: def}
+			that.values[name] = v
+			that.flags.Var(v, name, strings.TrimSpace(doc+" (repeatable)"))
+			continue
+		}
+
+		// Scalars are defined by standard flags, so help shows their types
+		v := &flagValue{kind: kind, scalar: that.defineScalar(name, kind, doc)}
+		if def != "" {
+			if err := v.scalar.Set(def); err == nil {
+				that.flags.Lookup(name).DefValue = v.scalar.String()
+			}
+		}
+		that.values[name] = v
+	}
+}
+
+func (that *FlagSource) defineScalar(name string, kind flagKind, doc string) flag.Getter {
+	switch kind {
+	case flagBool:
+		that.flags.Bool(name, false, doc)
+	case flagInt:
+		that.flags.Int64(name, 0, doc)
+	case flagUint:
+		that.flags.Uint64(name, 0, doc)
+	case flagFloat:
+		that.flags.Float64(name, 0, doc)
+	case flagDuration:
+		that.flags.Duration(name, 0, doc)
+	default:
+		that.flags.String(name, "", doc)
+	}
+	return that.flags.Lookup(name).Value.(flag.Getter)
+}
+
+type flagKind int
+
+const (
+	flagNone flagKind = iota
+	flagBool
+	flagInt
+	flagUint
+	flagFloat
+	flagString
+	flagDuration
+)
+
+func flagKindOf(tp reflect.Type) (kind flagKind, list bool) {
+	if tp == durationType {
+		return flagDuration, false
+	}
+	if tp == timeType {
+		return flagString, false
+	}
+
+	switch tp.Kind() {
+	case reflect.Bool:
+		return flagBool, false
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return flagInt, false
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return flagUint, false
+	case reflect.Float32, reflect.Float64:
+		return flagFloat, false
+	case reflect.String:
+		return flagString, false
+	case reflect.Slice:
+		if tp.Elem().Kind() == reflect.Uint8 {
+			return flagString, false
+		}
+		// Lists of scalars are filled by repeated flags
+		if kind, list := flagKindOf(tp.Elem()); !list && kind != flagNone {
+			return kind, true
+		}
+	}
+
+	return flagNone, false
+}
+
+func defaultOf(value reflect.Value) string {
+	if !value.IsValid() || value.IsZero() {
+		return ""
+	}
+
+	data, err := exportValue(value)
+	if err != nil || data == nil {
+		return ""
+	}
+
+	if list, ok := data.([]interface{}); ok {
+		items := make([]string, len(list))
+		for i, item := range list {
+			items[i] = fmt.Sprint(item)
+		}
+		return strings.Join(items, ",")
+	}
+
+	return fmt.Sprint(data)
+}
+
+// flagValue implements flag.Value of list and checks items by type of field.
+// Values of scalars are kept by standard flags.
+type flagValue struct {
+	kind   flagKind
+	scalar flag.Getter
+	def    string
+	values []interface{}
+}
+
+func (that *flagValue) String() string {
+	if that == nil {
+		return ""
+	}
+	if len(that.values) == 0 {
+		return that.def
+	}
+
+	items := make([]string, len(that.values))
+	for i, item := range that.values {
+		items[i] = fmt.Sprint(item)
+	}
+	return strings.Join(items, ",")
+}
+
+func (that *flagValue) Set(s string) error {
+	value, err := that.parse(s)
+	if err != nil {
+		return err
+	}
+
+	that.values = append(that.values, value)
+	return nil
+}
+
+func (that *flagValue) get() interface{} {
+	if that.scalar == nil {
+		return that.values
+	}
+
+	value := that.scalar.Get()
+	if d, ok := value.(time.Duration); ok {
+		// Durations are kept as strings, because schema and converters expect them
+		return d.String()
+	}
+	return value
+}
+
+func (that *flagValue) parse(s string) (interface{}, error) {
+	switch that.kind {
+	case flagBool:
+		return strconv.ParseBool(s)
+	case flagInt:
+		return strconv.ParseInt(s, 0, 64)
+	case flagUint:
+		return strconv.ParseUint(s, 0, 64)
+	case flagFloat:
+		return strconv.ParseFloat(s, 64)
+	case flagDuration:
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return nil, err
+		}
+		return d.String(), nil
+	}
+	return s, nil
+}
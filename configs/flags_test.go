@@ -0,0 +1,103 @@
+package configs
+
+import (
+	"bytes"
+	"flag"
+	"testing"
+	"time"
+
+	"github.com/adverax/metacrm.kernel/access/fetchers/maps/maps"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type flagTestAddress struct {
+	Host string `config:"host" doc:"Host of the server"`
+	Port int    `config:"port" doc:"Port of the server"`
+}
+
+type flagTestConfig struct {
+	Address flagTestAddress `config:"address"`
+	Debug   bool            `config:"debug"`
+	Timeout time.Duration   `config:"timeout"`
+	Tags    []string        `config:"tags"`
+}
+
+func TestFlagSource(t *testing.T) {
+	config := &flagTestConfig{
+		Address: flagTestAddress{Host: "localhost", Port: 80},
+		Timeout: time.Second,
+	}
+
+	source, err := NewFlagSource(config, []string{
+		"--address.port=8080",
+		"--debug",
+		"--timeout", "5s",
+		"--tags", "a",
+		"--tags", "b",
+		"run",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"run"}, source.Args())
+
+	data, err := source.Fetch()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"address": map[string]interface{}{"port": int64(8080)},
+		"debug":   true,
+		"timeout": "5s",
+		"tags":    []interface{}{"a", "b"},
+	}, data)
+
+	loader, err := NewBuilder().
+		WithSource(maps.Engine{"address": map[string]interface{}{"host": "example.com", "port": 81}}).
+		WithSource(source).
+		Build()
+	require.NoError(t, err)
+
+	err = loader.Load(config)
+	require.NoError(t, err)
+	assert.Equal(t, &flagTestConfig{
+		Address: flagTestAddress{Host: "example.com", Port: 8080},
+		Debug:   true,
+		Timeout: 5 * time.Second,
+		Tags:    []string{"a", "b"},
+	}, config)
+}
+
+func TestFlagSource_Usage(t *testing.T) {
+	config := &flagTestConfig{Address: flagTestAddress{Host: "localhost"}}
+	flags := flag.NewFlagSet("app", flag.ContinueOnError)
+	var output bytes.Buffer
+	flags.SetOutput(&output)
+	RegisterFlags(flags, config)
+
+	flags.PrintDefaults()
+	assert.Contains(t, output.String(), "-address.host string\n    \tHost of the server (default \"localhost\")")
+	assert.Contains(t, output.String(), "-address.port int\n    \tPort of the server\n")
+	assert.Contains(t, output.String(), "-timeout duration\n")
+	assert.Contains(t, output.String(), "-tags value\n    \t(repeatable)")
+	assert.Contains(t, output.String(), "-debug\n")
+
+	err := flags.Parse([]string{"--address.port=http"})
+	assert.Error(t, err)
+}
+
+func TestFlagSource_Schema(t *testing.T) {
+	config := &flagTestConfig{}
+	schema, err := SchemaOf(config)
+	require.NoError(t, err)
+
+	source, err := NewFlagSource(config, []string{"--timeout=5s", "--address.port=8080"})
+	require.NoError(t, err)
+
+	loader, err := NewBuilder().
+		WithSource(source).
+		WithSchema(schema).
+		Build()
+	require.NoError(t, err)
+
+	require.NoError(t, loader.Load(config))
+	assert.Equal(t, 5*time.Second, config.Timeout)
+	assert.Equal(t, 8080, config.Address.Port)
+}
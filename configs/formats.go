@@ -0,0 +1,109 @@
+package configs
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	dotenvFetcher "github.com/adverax/metacrm.kernel/access/fetchers/maps/dotenv"
+	jsonFetcher "github.com/adverax/metacrm.kernel/access/fetchers/maps/json"
+	tomlFetcher "github.com/adverax/metacrm.kernel/access/fetchers/maps/toml"
+	yamlFetcher "github.com/adverax/metacrm.kernel/access/fetchers/maps/yaml"
+)
+
+// Formats is registry of source builders, keyed by file extension.
+type Formats struct {
+	mx       sync.RWMutex
+	builders map[string]SourceBuilder
+}
+
+func NewFormats() *Formats {
+	return &Formats{
+		builders: make(map[string]SourceBuilder),
+	}
+}
+
+// Register registers builder for extension (for example ".yaml").
+func (that *Formats) Register(ext string, builder SourceBuilder) *Formats {
+	that.mx.Lock()
+	defer that.mx.Unlock()
+
+	that.builders[normalizeExt(ext)] = builder
+	return that
+}
+
+// Get returns builder for extension or nil.
+func (that *Formats) Get(ext string) SourceBuilder {
+	that.mx.RLock()
+	defer that.mx.RUnlock()
+
+	return that.builders[normalizeExt(ext)]
+}
+
+// Detect returns builder for file by the last extension.
+// Dot files are detected by the first extension, so ".env.local" is detected as ".env",
+// but "config.json.example" is unknown.
+func (that *Formats) Detect(filename string) (SourceBuilder, error) {
+	parts := strings.Split(filepath.Base(filename), ".")
+	if len(parts) > 1 {
+		if builder := that.Get(parts[len(parts)-1]); builder != nil {
+			return builder, nil
+		}
+		if parts[0] == "" {
+			if builder := that.Get(parts[1]); builder != nil {
+				return builder, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %q", ErrUnknownFormat, filename)
+}
+
+func normalizeExt(ext string) string {
+	return "." + strings.ToLower(strings.TrimPrefix(ext, "."))
+}
+
+// RegisterFormat registers builder for extension globally.
+func RegisterFormat(ext string, builder SourceBuilder) {
+	formats.Register(ext, builder)
+}
+
+// FormatOf returns globally registered builder for file.
+func FormatOf(filename string) (SourceBuilder, error) {
+	return formats.Detect(filename)
+}
+
+func newJsonSource(fetcher Fetcher) Source {
+	return jsonFetcher.New(fetcher)
+}
+
+func newYamlSource(fetcher Fetcher) Source {
+	return yamlFetcher.New(fetcher)
+}
+
+func newTomlSource(fetcher Fetcher) Source {
+	return tomlFetcher.New(fetcher)
+}
+
+// NewDotenvSource makes source, that maps variables like ADDRESS_HOST into nested keys address.host.
+func NewDotenvSource(fetcher Fetcher) Source {
+	engine, _ := dotenvFetcher.NewBuilder().
+		WithFetcher(fetcher).
+		WithDelimiter("_").
+		WithLowerCase(true).
+		Build()
+	return engine
+}
+
+var formats = NewFormats().
+	Register(".json", newJsonSource).
+	Register(".yaml", newYamlSource).
+	Register(".yml", newYamlSource).
+	Register(".toml", newTomlSource).
+	Register(".env", NewDotenvSource)
+
+var (
+	ErrUnknownFormat = errors.New("unknown config format")
+)
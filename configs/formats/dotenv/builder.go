@@ -0,0 +1,13 @@
+package dotenvConfig
+
+import "github.com/adverax/metacrm.kernel/configs"
+
+// NewSource makes source, that maps variables like ADDRESS_HOST into nested keys address.host.
+func NewSource(fetcher configs.Fetcher) configs.Source {
+	return configs.NewDotenvSource(fetcher)
+}
+
+func NewFileLoaderBuilder() *configs.FileLoaderBuilder {
+	return configs.NewFileLoaderBuilder().
+		WithSourceBuilder(NewSource)
+}
@@ -3,7 +3,7 @@ package mixedConfig
 import (
 	"fmt"
 	envFetcher "github.com/adverax/metacrm.kernel/access/fetchers/maps/env"
-	yamlConfig "github.com/adverax/metacrm.kernel/configs/formats/yaml"
+	"github.com/adverax/metacrm.kernel/configs"
 )
 
 type MyConfigAddress struct {
@@ -30,7 +30,7 @@ func Example() {
 	// This example demonstrates how to use Mixed loader.
 	//
 	// First, create loader:
-	loader, err := yamlConfig.NewFileLoaderBuilder().
+	loader, err := configs.NewFileLoaderBuilder().
 		WithFile("config.global.json", false).
 		WithFile("config.local.json", false).
 		WithSource(
@@ -58,3 +58,30 @@ func Example() {
 	// Output:
 	// {{google.com 91} My App}
 }
+
+func Example_tomlAndDotenv() {
+	// This example demonstrates how to combine TOML file with dotenv file.
+	//
+	// First, create loader (formats are detected by extensions of files):
+	loader, err := configs.NewFileLoaderBuilder().
+		WithFile("config.toml", false).
+		WithFile(".env.local", false).
+		Build()
+	if err != nil {
+		panic(err)
+	}
+
+	// Then load configuration:
+	config := DefaultConfig()
+	err = loader.Load(config)
+	if err != nil {
+		panic(err)
+	}
+
+	// Now you can use config.
+	// For example, print it:
+	fmt.Println(*config)
+
+	// Output:
+	// {{google.com 91} My App}
+}
@@ -0,0 +1,15 @@
+package tomlConfig
+
+import (
+	"github.com/adverax/metacrm.kernel/access/fetchers/maps/toml"
+	"github.com/adverax/metacrm.kernel/configs"
+)
+
+func NewFileLoaderBuilder() *configs.FileLoaderBuilder {
+	return configs.NewFileLoaderBuilder().
+		WithSourceBuilder(
+			func(fetcher configs.Fetcher) configs.Source {
+				return tomlFetcher.New(fetcher)
+			},
+		)
+}
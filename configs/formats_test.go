@@ -0,0 +1,55 @@
+package configs
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormats_Detect(t *testing.T) {
+	yaml := func(Fetcher) Source { return nil }
+	env := func(Fetcher) Source { return nil }
+	registry := NewFormats().
+		Register("yaml", yaml).
+		Register(".ENV", env)
+
+	tests := []struct {
+		filename string
+		expected SourceBuilder
+	}{
+		{filename: "config.yaml", expected: yaml},
+		{filename: "/etc/app/config.prod.YAML", expected: yaml},
+		{filename: ".env", expected: env},
+		{filename: ".env.local", expected: env},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filename, func(t *testing.T) {
+			actual, err := registry.Detect(tt.filename)
+			require.NoError(t, err)
+			assert.Equal(t, reflect.ValueOf(tt.expected).Pointer(), reflect.ValueOf(actual).Pointer())
+		})
+	}
+
+	_, err := registry.Detect("config.json")
+	assert.ErrorIs(t, err, ErrUnknownFormat)
+	_, err = registry.Detect("config")
+	assert.ErrorIs(t, err, ErrUnknownFormat)
+	_, err = registry.Detect("config.yaml.example")
+	assert.ErrorIs(t, err, ErrUnknownFormat)
+}
+
+func TestFileLoaderBuilder_UnknownFormat(t *testing.T) {
+	_, err := NewFileLoaderBuilder().
+		WithFile("config.ini", false).
+		Build()
+	assert.ErrorIs(t, err, ErrUnknownFormat)
+
+	_, err = NewFileLoaderBuilder().
+		WithFormat(".ini", newJsonSource).
+		WithFile("config.ini", false).
+		Build()
+	assert.NoError(t, err)
+}
@@ -0,0 +1,283 @@
+package configs
+
+import (
+	"reflect"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// Change describes replacement of config snapshot.
+type Change[T any] struct {
+	Old  *T
+	New  *T
+	Diff []string // config names of changed fields (dot separated)
+}
+
+// Holder keeps immutable snapshot of config, that can be read concurrently without locks.
+// Snapshots must not be modified after publishing: use Update instead.
+// Subscribers are notified after the holder is unlocked, so they may call Update.
+// Changes are delivered in order of writes: if other writer is notifying subscribers,
+// the change is queued and delivered by that writer.
+type Holder[T any] struct {
+	mx          sync.Mutex
+	value       atomic.Pointer[T]
+	subscribers map[int]func(change *Change[T])
+	sequence    int
+	pending     []pendingChange[T]
+	notifying   bool
+}
+
+type pendingChange[T any] struct {
+	change   *Change[T]
+	handlers []func(change *Change[T])
+}
+
+func NewHolder[T any](config *T) *Holder[T] {
+	holder := &Holder[T]{
+		subscribers: make(map[int]func(change *Change[T])),
+	}
+	holder.value.Store(config)
+	return holder
+}
+
+// Get returns current snapshot.
+func (that *Holder[T]) Get() *T {
+	return that.value.Load()
+}
+
+// Store replaces current snapshot and notifies subscribers.
+// It is compatible with handlers of Watcher.
+func (that *Holder[T]) Store(config *T) {
+	that.mx.Lock()
+	that.publish(config)
+}
+
+// Update applies action to the copy of current snapshot and publishes it.
+func (that *Holder[T]) Update(action func(config *T)) {
+	that.mx.Lock()
+	config := Clone(that.value.Load())
+	action(config)
+	that.publish(config)
+}
+
+// Load loads config by loader into the fresh struct, built by factory (defaults), and publishes it.
+// So values, removed from sources, are reset to defaults.
+// Returns ErrDistinct, when config was not changed.
+func (that *Holder[T]) Load(loader *Loader, factory func() *T) error {
+	config := factory()
+	err := loader.Load(config)
+	if err != nil {
+		return err
+	}
+
+	that.mx.Lock()
+	that.publish(config)
+	return nil
+}
+
+// Subscribe registers handler of changes. Returns function for unsubscribe.
+func (that *Holder[T]) Subscribe(handler func(change *Change[T])) (unsubscribe func()) {
+	that.mx.Lock()
+	defer that.mx.Unlock()
+
+	that.sequence++
+	id := that.sequence
+	that.subscribers[id] = handler
+
+	return func() {
+		that.mx.Lock()
+		defer that.mx.Unlock()
+
+		delete(that.subscribers, id)
+	}
+}
+
+// publish replaces snapshot and notifies subscribers. It must be called under the lock and unlocks it.
+func (that *Holder[T]) publish(config *T) {
+	change, handlers := that.swap(config)
+	if change != nil {
+		that.pending = append(that.pending, pendingChange[T]{change: change, handlers: handlers})
+	}
+	if that.notifying || len(that.pending) == 0 {
+		that.mx.Unlock()
+		return
+	}
+	that.notifying = true
+	that.mx.Unlock()
+
+	defer func() {
+		if r := recover(); r != nil {
+			that.mx.Lock()
+			that.notifying = false
+			that.mx.Unlock()
+			panic(r)
+		}
+	}()
+
+	for {
+		that.mx.Lock()
+		if len(that.pending) == 0 {
+			that.notifying = false
+			that.mx.Unlock()
+			return
+		}
+		item := that.pending[0]
+		that.pending = that.pending[1:]
+		that.mx.Unlock()
+
+		notify(item.change, item.handlers)
+	}
+}
+
+// swap replaces snapshot and returns change with handlers to notify (nil, if nothing is changed).
+func (that *Holder[T]) swap(config *T) (*Change[T], []func(change *Change[T])) {
+	old := that.value.Swap(config)
+	if len(that.subscribers) == 0 {
+		return nil, nil
+	}
+
+	change := &Change[T]{
+		Old:  old,
+		New:  config,
+		Diff: Diff(old, config),
+	}
+	if len(change.Diff) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]int, 0, len(that.subscribers))
+	for id := range that.subscribers {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	handlers := make([]func(change *Change[T]), len(ids))
+	for i, id := range ids {
+		handlers[i] = that.subscribers[id]
+	}
+	return change, handlers
+}
+
+func notify[T any](change *Change[T], handlers []func(change *Change[T])) {
+	for _, handler := range handlers {
+		handler(change)
+	}
+}
+
+// Clone makes deep copy of config struct.
+// Interfaces (dynamic values) are shared between copies, sync primitives (see BaseConfig) are reset.
+func Clone[T any](config *T) *T {
+	res := new(T)
+	if config == nil {
+		return res
+	}
+
+	val := reflect.ValueOf(res).Elem()
+	val.Set(reflect.ValueOf(config).Elem())
+	cloneValue(val)
+	return res
+}
+
+func cloneValue(val reflect.Value) {
+	switch val.Kind() {
+	case reflect.Struct:
+		if val.Type().PkgPath() == "sync" {
+			val.Set(reflect.Zero(val.Type()))
+			return
+		}
+		for i := 0; i < val.NumField(); i++ {
+			if field := val.Field(i); field.CanSet() {
+				cloneValue(field)
+			}
+		}
+	case reflect.Ptr:
+		if val.IsNil() {
+			return
+		}
+		cp := reflect.New(val.Type().Elem())
+		cp.Elem().Set(val.Elem())
+		cloneValue(cp.Elem())
+		val.Set(cp)
+	case reflect.Slice:
+		if val.IsNil() {
+			return
+		}
+		cp := reflect.MakeSlice(val.Type(), val.Len(), val.Len())
+		reflect.Copy(cp, val)
+		for i := 0; i < cp.Len(); i++ {
+			cloneValue(cp.Index(i))
+		}
+		val.Set(cp)
+	case reflect.Map:
+		if val.IsNil() {
+			return
+		}
+		cp := reflect.MakeMapWithSize(val.Type(), val.Len())
+		iter := val.MapRange()
+		for iter.Next() {
+			item := reflect.New(val.Type().Elem()).Elem()
+			item.Set(iter.Value())
+			cloneValue(item)
+			cp.SetMapIndex(iter.Key(), item)
+		}
+		val.Set(cp)
+	}
+}
+
+// Diff returns config names of fields, that are different in a and b.
+func Diff(a, b interface{}) []string {
+	va := reflect.Indirect(reflect.ValueOf(a))
+	vb := reflect.Indirect(reflect.ValueOf(b))
+	if !va.IsValid() || !vb.IsValid() {
+		if va.IsValid() != vb.IsValid() {
+			return []string{""}
+		}
+		return nil
+	}
+
+	var res []string
+	diffValues(&res, "", va, vb)
+	return res
+}
+
+func diffValues(res *[]string, path string, a, b reflect.Value) {
+	if a.Kind() != reflect.Struct || a.Type() != b.Type() || !hasExportedFields(a.Type()) {
+		if !reflect.DeepEqual(a.Interface(), b.Interface()) {
+			*res = append(*res, path)
+		}
+		return
+	}
+
+	tp := a.Type()
+	for i := 0; i < a.NumField(); i++ {
+		field := tp.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, ok := NameOf(field)
+		if !ok {
+			continue
+		}
+
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			diffValues(res, path, a.Field(i), b.Field(i))
+			continue
+		}
+
+		if path != "" {
+			name = path + "." + name
+		}
+		diffValues(res, name, a.Field(i), b.Field(i))
+	}
+}
+
+func hasExportedFields(tp reflect.Type) bool {
+	for i := 0; i < tp.NumField(); i++ {
+		if tp.Field(i).IsExported() {
+			return true
+		}
+	}
+	return false
+}
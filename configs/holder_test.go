@@ -0,0 +1,161 @@
+package configs
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/adverax/metacrm.kernel/access/fetchers/maps/maps"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type heldAddress struct {
+	Host string `config:"host"`
+	Port int    `config:"port"`
+}
+
+type heldConfig struct {
+	Address heldAddress `config:"address"`
+	Name    string      `config:"name"`
+	Tags    []string    `config:"tags"`
+}
+
+func TestHolder_Update(t *testing.T) {
+	original := &heldConfig{
+		Address: heldAddress{Host: "localhost", Port: 80},
+		Name:    "app",
+		Tags:    []string{"a"},
+	}
+	holder := NewHolder(original)
+
+	var changes []*Change[heldConfig]
+	unsubscribe := holder.Subscribe(func(change *Change[heldConfig]) {
+		changes = append(changes, change)
+	})
+
+	holder.Update(func(config *heldConfig) {
+		config.Address.Port = 81
+		config.Tags[0] = "b"
+	})
+
+	require.Len(t, changes, 1)
+	assert.Same(t, original, changes[0].Old)
+	assert.Same(t, holder.Get(), changes[0].New)
+	assert.Equal(t, []string{"address.port", "tags"}, changes[0].Diff)
+	assert.Equal(t, 80, original.Address.Port)
+	assert.Equal(t, []string{"a"}, original.Tags)
+
+	unsubscribe()
+	holder.Update(func(config *heldConfig) {
+		config.Name = "other"
+	})
+	assert.Len(t, changes, 1)
+	assert.Equal(t, "other", holder.Get().Name)
+}
+
+func TestHolder_ConcurrentAccess(t *testing.T) {
+	holder := NewHolder(&heldConfig{})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				holder.Update(func(config *heldConfig) {
+					config.Address.Port++
+				})
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				_ = holder.Get().Address.Port
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 400, holder.Get().Address.Port)
+}
+
+func TestHolder_NotifyInOrder(t *testing.T) {
+	holder := NewHolder(&heldConfig{})
+
+	var changes []*Change[heldConfig]
+	holder.Subscribe(func(change *Change[heldConfig]) {
+		changes = append(changes, change)
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				holder.Update(func(config *heldConfig) {
+					config.Address.Port++
+				})
+			}
+		}()
+	}
+	wg.Wait()
+
+	require.Len(t, changes, 200)
+	for i, change := range changes {
+		assert.Equal(t, i+1, change.New.Address.Port)
+	}
+	assert.Same(t, holder.Get(), changes[len(changes)-1].New)
+}
+
+func TestHolder_Load(t *testing.T) {
+	factory := func() *heldConfig {
+		return &heldConfig{Name: "default"}
+	}
+
+	holder := NewHolder(factory())
+	source := maps.Engine{"name": "app", "tags": []interface{}{"a"}}
+	loader, err := NewBuilder().WithSource(source).Build()
+	require.NoError(t, err)
+
+	require.NoError(t, holder.Load(loader, factory))
+	assert.Equal(t, &heldConfig{Name: "app", Tags: []string{"a"}}, holder.Get())
+
+	delete(source, "name")
+	require.NoError(t, holder.Load(loader, factory))
+	assert.Equal(t, &heldConfig{Name: "default", Tags: []string{"a"}}, holder.Get())
+}
+
+func TestHolder_SubscriberUpdates(t *testing.T) {
+	holder := NewHolder(&heldConfig{})
+
+	holder.Subscribe(func(change *Change[heldConfig]) {
+		if change.New.Name == "app" {
+			holder.Update(func(config *heldConfig) {
+				config.Name = "app-2"
+			})
+		}
+	})
+
+	holder.Update(func(config *heldConfig) {
+		config.Name = "app"
+	})
+	assert.Equal(t, "app-2", holder.Get().Name)
+}
+
+func TestClone_ResetsLocks(t *testing.T) {
+	type lockedConfig struct {
+		BaseConfig
+		Name string `config:"name"`
+	}
+
+	config := &lockedConfig{Name: "app"}
+	config.Lock()
+	defer config.Unlock()
+
+	clone := Clone(config)
+	require.True(t, clone.TryLock())
+	clone.Unlock()
+	assert.Equal(t, "app", clone.Name)
+}
@@ -0,0 +1,168 @@
+package configs
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Interpolator expands references inside string values:
+//   - ${VAR} - value of environment variable (empty, if variable is not defined);
+//   - ${VAR:-default} - value of environment variable or default, if it is empty;
+//   - ${file:/path/to/file} - content of file without trailing line breaks;
+//   - $$ - escaped dollar sign.
+//
+// Values without references are not changed, so "$$" is kept as is in them (for example in passwords).
+type Interpolator struct {
+	Lookup   func(key string) (string, bool)
+	ReadFile func(filename string) ([]byte, error)
+}
+
+// Expand expands all references inside text. Text without references is returned as is.
+func (that *Interpolator) Expand(text string) (string, error) {
+	if !strings.Contains(text, "${") {
+		return text, nil
+	}
+
+	var buf strings.Builder
+	for i := 0; i < len(text); i++ {
+		ch := text[i]
+		if ch != '$' || i+1 == len(text) {
+			buf.WriteByte(ch)
+			continue
+		}
+
+		switch text[i+1] {
+		case '$':
+			buf.WriteByte('$')
+			i++
+		case '{':
+			end := strings.IndexByte(text[i+2:], '}')
+			if end < 0 {
+				return "", fmt.Errorf("%w: unclosed reference in %q", ErrInterpolation, text)
+			}
+			value, err := that.resolve(text[i+2 : i+2+end])
+			if err != nil {
+				return "", err
+			}
+			buf.WriteString(value)
+			i += end + 2
+		default:
+			buf.WriteByte(ch)
+		}
+	}
+
+	return buf.String(), nil
+}
+
+// ExpandAll returns copy of data with expanded string values.
+func (that *Interpolator) ExpandAll(data map[string]interface{}) (map[string]interface{}, error) {
+	res, err := that.expandValue(data)
+	if err != nil {
+		return nil, err
+	}
+	return res.(map[string]interface{}), nil
+}
+
+func (that *Interpolator) expandValue(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		return that.Expand(v)
+	case map[string]interface{}:
+		res := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			val, err := that.expandValue(item)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", key, err)
+			}
+			res[key] = val
+		}
+		return res, nil
+	case []interface{}:
+		res := make([]interface{}, len(v))
+		for i, item := range v {
+			val, err := that.expandValue(item)
+			if err != nil {
+				return nil, fmt.Errorf("%d: %w", i, err)
+			}
+			res[i] = val
+		}
+		return res, nil
+	default:
+		return value, nil
+	}
+}
+
+func (that *Interpolator) resolve(expr string) (string, error) {
+	name, def, hasDefault := strings.Cut(expr, ":-")
+
+	if filename, ok := strings.CutPrefix(name, "file:"); ok {
+		data, err := that.ReadFile(filename)
+		if err != nil {
+			if hasDefault && os.IsNotExist(err) {
+				return def, nil
+			}
+			return "", fmt.Errorf("%w: %w", ErrInterpolation, err)
+		}
+		return strings.TrimRight(string(data), "\r\n"), nil
+	}
+
+	if name == "" {
+		return "", fmt.Errorf("%w: empty reference", ErrInterpolation)
+	}
+
+	value, _ := that.Lookup(name)
+	if value == "" && hasDefault {
+		return def, nil
+	}
+
+	return value, nil
+}
+
+var DefaultInterpolator = &Interpolator{
+	Lookup:   os.LookupEnv,
+	ReadFile: os.ReadFile,
+}
+
+// InterpolatedSource is decorator of the Source, that expands references inside string values.
+type InterpolatedSource struct {
+	source       Source
+	interpolator *Interpolator
+}
+
+// NewInterpolatedSource makes decorator. If interpolator is nil, then DefaultInterpolator is used.
+func NewInterpolatedSource(source Source, interpolator *Interpolator) *InterpolatedSource {
+	if interpolator == nil {
+		interpolator = DefaultInterpolator
+	}
+
+	return &InterpolatedSource{
+		source:       source,
+		interpolator: interpolator,
+	}
+}
+
+// Locate delegates to the decorated source.
+func (that *InterpolatedSource) Locate(path []string) (file string, line, column int, ok bool) {
+	if locator, ok := that.source.(Locator); ok {
+		return locator.Locate(path)
+	}
+	return "", 0, 0, false
+}
+
+func (that *InterpolatedSource) Fetch() (map[string]interface{}, error) {
+	data, err := that.source.Fetch()
+	if err != nil {
+		return nil, err
+	}
+
+	if data == nil {
+		return nil, nil
+	}
+
+	return that.interpolator.ExpandAll(data)
+}
+
+var (
+	ErrInterpolation = fmt.Errorf("interpolation error")
+)
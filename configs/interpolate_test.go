@@ -0,0 +1,81 @@
+package configs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/adverax/metacrm.kernel/access/fetchers/maps/maps"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterpolator_Expand(t *testing.T) {
+	secret := filepath.Join(t.TempDir(), "db_password")
+	require.NoError(t, os.WriteFile(secret, []byte("s3cr3t\n"), 0600))
+
+	interpolator := &Interpolator{
+		Lookup: func(key string) (string, bool) {
+			vars := map[string]string{"HOST": "example.com", "EMPTY": ""}
+			v, ok := vars[key]
+			return v, ok
+		},
+		ReadFile: os.ReadFile,
+	}
+
+	tests := []struct {
+		name     string
+		text     string
+		expected string
+		err      bool
+	}{
+		{name: "plain", text: "localhost", expected: "localhost"},
+		{name: "variable", text: "http://${HOST}:80", expected: "http://example.com:80"},
+		{name: "undefined", text: "${UNKNOWN}", expected: ""},
+		{name: "default", text: "${UNKNOWN:-127.0.0.1}", expected: "127.0.0.1"},
+		{name: "default for empty", text: "${EMPTY:-x}", expected: "x"},
+		{name: "defined with default", text: "${HOST:-x}", expected: "example.com"},
+		{name: "escaped", text: "$${HOST} costs $5", expected: "${HOST} costs $5"},
+		{name: "without references", text: "pa$$word", expected: "pa$$word"},
+		{name: "file", text: "${file:" + secret + "}", expected: "s3cr3t"},
+		{name: "missing file with default", text: "${file:/not/exists:-none}", expected: "none"},
+		{name: "missing file", text: "${file:/not/exists}", err: true},
+		{name: "unclosed", text: "${HOST", err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := interpolator.Expand(tt.text)
+			if tt.err {
+				assert.ErrorIs(t, err, ErrInterpolation)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestInterpolatedSource_Fetch(t *testing.T) {
+	t.Setenv("MYAPP_TEST_HOST", "example.com")
+
+	origin := maps.Engine{
+		"address": map[string]interface{}{
+			"host": "${MYAPP_TEST_HOST}",
+			"port": 80,
+		},
+		"names": []interface{}{"${MYAPP_TEST_HOST}"},
+	}
+
+	data, err := NewInterpolatedSource(origin, nil).Fetch()
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{
+		"address": map[string]interface{}{
+			"host": "example.com",
+			"port": 80,
+		},
+		"names": []interface{}{"example.com"},
+	}, data)
+	assert.Equal(t, "${MYAPP_TEST_HOST}", origin["address"].(map[string]interface{})["host"])
+}
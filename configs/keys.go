@@ -0,0 +1,188 @@
+package configs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+
+	"github.com/adverax/metacrm.kernel/access"
+	"github.com/adverax/metacrm.kernel/types"
+	"github.com/adverax/metacrm.kernel/types/convert"
+)
+
+// Key is typed path of config value:
+//
+//	var Port = configs.NewKey("address.port", 80, "Port of the server")
+//
+//	port, err := Port.Get(ctx, variables)
+//
+// Keys are registered in the default registry, when they are made by NewKey or first used,
+// so Keys lists all keys, that program reads.
+type Key[T any] struct {
+	Path    string // dot separated path
+	Default T
+	Doc     string
+}
+
+// NewKey makes key and registers it in the default registry.
+// It panics, if the path is already registered with another type,
+// so it is intended for initialization of package variables.
+func NewKey[T any](path string, def T, doc string) Key[T] {
+	key := Key[T]{Path: path, Default: def, Doc: doc}
+	if err := keys.Register(key.Info()); err != nil {
+		panic(err)
+	}
+	return key
+}
+
+// Name returns name of the key for the getter (path expression "$.path").
+func (that Key[T]) Name() string {
+	return "$." + that.Path
+}
+
+// Get returns value of the key or default value, if the key is absent.
+// Returns ErrKeyConflict, if the path is registered with another type.
+func (that Key[T]) Get(ctx context.Context, getter access.Getter) (T, error) {
+	if err := keys.ensure(that.Info()); err != nil {
+		return that.Default, err
+	}
+
+	raw, err := getter.GetProperty(ctx, that.Name())
+	if err != nil {
+		if errors.Is(err, types.ErrNoMatch) {
+			return that.Default, nil
+		}
+		return that.Default, err
+	}
+
+	if raw == nil {
+		return that.Default, nil
+	}
+
+	value, ok := convert.As[T](raw)
+	if !ok {
+		return that.Default, fmt.Errorf("%w: can't convert %v into %s with key %q", ErrWrongKeyType, raw, typeOf[T](), that.Path)
+	}
+
+	return value, nil
+}
+
+// Set stores value of the key.
+func (that Key[T]) Set(ctx context.Context, setter access.Setter, value T) error {
+	if err := keys.ensure(that.Info()); err != nil {
+		return err
+	}
+
+	return setter.SetProperty(ctx, that.Name(), value)
+}
+
+// Info returns description of the key.
+func (that Key[T]) Info() KeyInfo {
+	return KeyInfo{
+		Path:    that.Path,
+		Type:    typeOf[T](),
+		Default: that.Default,
+		Doc:     that.Doc,
+	}
+}
+
+// KeyInfo is description of the key.
+type KeyInfo struct {
+	Path    string
+	Type    reflect.Type
+	Default interface{}
+	Doc     string
+}
+
+// KeyRegistry is registry of keys, that program reads.
+type KeyRegistry struct {
+	mx   sync.RWMutex
+	keys map[string]KeyInfo
+}
+
+func NewKeyRegistry() *KeyRegistry {
+	return &KeyRegistry{
+		keys: make(map[string]KeyInfo),
+	}
+}
+
+// Register registers key. Key with the same path must have the same type.
+func (that *KeyRegistry) Register(info KeyInfo) error {
+	that.mx.Lock()
+	defer that.mx.Unlock()
+
+	if old, ok := that.keys[info.Path]; ok {
+		if err := checkKeyType(old, info); err != nil {
+			return err
+		}
+	}
+
+	that.keys[info.Path] = info
+	return nil
+}
+
+// ensure registers key, if it is not registered yet. Registered key is not replaced.
+func (that *KeyRegistry) ensure(info KeyInfo) error {
+	that.mx.RLock()
+	old, ok := that.keys[info.Path]
+	that.mx.RUnlock()
+	if ok {
+		return checkKeyType(old, info)
+	}
+
+	that.mx.Lock()
+	defer that.mx.Unlock()
+
+	if old, ok := that.keys[info.Path]; ok {
+		return checkKeyType(old, info)
+	}
+
+	that.keys[info.Path] = info
+	return nil
+}
+
+// Keys returns registered keys, sorted by path.
+func (that *KeyRegistry) Keys() []KeyInfo {
+	that.mx.RLock()
+	defer that.mx.RUnlock()
+
+	res := make([]KeyInfo, 0, len(that.keys))
+	for _, info := range that.keys {
+		res = append(res, info)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Path < res[j].Path
+	})
+	return res
+}
+
+// Keys returns keys of the default registry.
+func Keys() []KeyInfo {
+	return keys.Keys()
+}
+
+// RegisterKey registers key in the default registry.
+func RegisterKey(info KeyInfo) error {
+	return keys.Register(info)
+}
+
+func checkKeyType(old, info KeyInfo) error {
+	if old.Type != info.Type {
+		return fmt.Errorf("%w: key %q is registered as %s, got %s", ErrKeyConflict, info.Path, old.Type, info.Type)
+	}
+	return nil
+}
+
+func typeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+var keys = NewKeyRegistry()
+
+var (
+	ErrWrongKeyType = errors.New("wrong type of key")
+	ErrKeyConflict  = errors.New("key conflict")
+)
@@ -0,0 +1,70 @@
+package configs
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/adverax/metacrm.kernel/containers/maps"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKey_Get(t *testing.T) {
+	ctx := context.Background()
+	data := maps.Map{
+		"name":  "My App",
+		"title": "Admin",
+		"address": maps.Map{
+			"port": "8080",
+		},
+	}
+
+	port, err := Key[int]{Path: "address.port", Default: 80}.Get(ctx, data)
+	require.NoError(t, err)
+	assert.Equal(t, 8080, port)
+
+	name, err := Key[string]{Path: "name"}.Get(ctx, data)
+	require.NoError(t, err)
+	assert.Equal(t, "My App", name)
+
+	host, err := Key[string]{Path: "address.host", Default: "localhost"}.Get(ctx, data)
+	require.NoError(t, err)
+	assert.Equal(t, "localhost", host)
+
+	_, err = Key[int]{Path: "title"}.Get(ctx, data)
+	assert.ErrorIs(t, err, ErrWrongKeyType)
+
+	// literal keys are registered on use
+	assert.Contains(t, Keys(), Key[string]{Path: "address.host", Default: "localhost"}.Info())
+	_, err = Key[bool]{Path: "address.host"}.Get(ctx, data)
+	assert.ErrorIs(t, err, ErrKeyConflict)
+
+	err = Key[string]{Path: "address.host"}.Set(ctx, data, "example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "example.com", data["address"].(maps.Map)["host"])
+}
+
+func TestKeyRegistry(t *testing.T) {
+	registry := NewKeyRegistry()
+	require.NoError(t, registry.Register(Key[int]{Path: "address.port", Default: 80}.Info()))
+	require.NoError(t, registry.Register(Key[string]{Path: "address.host", Doc: "Host"}.Info()))
+	require.NoError(t, registry.Register(Key[int]{Path: "address.port", Default: 81}.Info()))
+
+	err := registry.Register(Key[string]{Path: "address.port"}.Info())
+	assert.ErrorIs(t, err, ErrKeyConflict)
+
+	assert.Equal(t, []KeyInfo{
+		{Path: "address.host", Type: reflect.TypeOf(""), Default: "", Doc: "Host"},
+		{Path: "address.port", Type: reflect.TypeOf(0), Default: 81},
+	}, registry.Keys())
+}
+
+func TestNewKey(t *testing.T) {
+	key := NewKey("test.new-key.port", 80, "Port")
+	assert.Contains(t, Keys(), key.Info())
+
+	assert.Panics(t, func() {
+		NewKey("test.new-key.port", "80", "Port")
+	})
+}
@@ -4,35 +4,66 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sync"
 )
 
 type Loader struct {
+	mx        sync.Mutex
 	sources   []Source
+	files     []string
 	converter Converter
+	merger    Merger
+	schema    *Schema
 	distinct  bool
 	hash      string
 }
 
+// Files returns list of files, that sources of loader are based on.
+func (that *Loader) Files() []string {
+	return that.files
+}
+
 func (that *Loader) Load(config interface{}) error {
+	that.mx.Lock()
+	defer that.mx.Unlock()
+
 	ds, err := that.load(that.sources...)
 	if err != nil {
 		return err
 	}
 
+	var hash string
 	if that.distinct {
-		hash := that.hashOf(ds)
+		hash = that.hashOf(ds)
 		if hash == that.hash {
 			return ErrDistinct
 		}
 	}
 
-	data := that.merge(ds)
+	err = that.validateSources(ds)
+	if err != nil {
+		return err
+	}
+
+	data, err := that.merge(config, ds)
+	if err != nil {
+		return err
+	}
+
+	if that.schema != nil {
+		var errs ValidationErrors
+		that.schema.validateRequired(&errs, nil, data)
+		if err := errs.Err(); err != nil {
+			return fmt.Errorf("error validate config: %w", err)
+		}
+	}
 
 	err = that.converter.Convert(config, data)
 	if err != nil {
 		return fmt.Errorf("error convert config: %w", err)
 	}
 
+	that.hash = hash
 	return nil
 }
 
@@ -49,14 +80,44 @@ func (that *Loader) load(sources ...Source) ([]map[string]interface{}, error) {
 	return ds, nil
 }
 
-func (that *Loader) merge(ds []map[string]interface{}) map[string]interface{} {
-	data := make(map[string]interface{})
+// validateSources checks types of values of each source, so errors refer to positions in files.
+func (that *Loader) validateSources(ds []map[string]interface{}) error {
+	if that.schema == nil {
+		return nil
+	}
 
+	var errs ValidationErrors
+	for i, d := range ds {
+		locator, _ := that.sources[i].(Locator)
+		that.schema.validateType(&errs, nil, d, locator)
+	}
+
+	if err := errs.Err(); err != nil {
+		return fmt.Errorf("error validate config: %w", err)
+	}
+
+	return nil
+}
+
+func (that *Loader) merge(config interface{}, ds []map[string]interface{}) (map[string]interface{}, error) {
+	merger := that.merger
+	if m, ok := merger.(RuledMerger); ok {
+		rules, err := RulesOf(config)
+		if err != nil {
+			return nil, fmt.Errorf("error merge config: %w", err)
+		}
+		merger = m.WithRules(rules)
+	}
+
+	data := make(map[string]interface{})
 	for _, d := range ds {
-		override(data, d)
+		err := merger.Merge(data, d)
+		if err != nil {
+			return nil, fmt.Errorf("error merge config: %w", err)
+		}
 	}
 
-	return data
+	return data, nil
 }
 
 func (that *Loader) hashOf(data []map[string]interface{}) string {
@@ -0,0 +1,361 @@
+package configs
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/adverax/metacrm.kernel/enums"
+)
+
+// MergeStrategy defines how values of the next source are combined with the previous ones.
+type MergeStrategy int
+
+func (that MergeStrategy) String() string {
+	return MergeStrategies.DecodeOrDefault(that, "unknown")
+}
+
+const (
+	// MergeReplace replaces previous value.
+	MergeReplace MergeStrategy = iota
+	// MergeAppend appends items of list to the previous list.
+	MergeAppend
+	// MergePrepend inserts items of list before items of the previous list.
+	MergePrepend
+	// MergeUnion appends items of list, that are absent in the previous list.
+	MergeUnion
+	// MergeByKey merges lists of objects: objects with the same key are merged deeply,
+	// other objects are appended.
+	MergeByKey
+)
+
+var MergeStrategies = enums.New[MergeStrategy](
+	map[MergeStrategy]string{
+		MergeReplace: "replace",
+		MergeAppend:  "append",
+		MergePrepend: "prepend",
+		MergeUnion:   "union",
+		MergeByKey:   "bykey",
+	},
+)
+
+// MergeRule is merge directive for the key.
+type MergeRule struct {
+	Strategy MergeStrategy
+	Key      string // key field of objects for MergeByKey
+}
+
+// MergeRules are merge directives, keyed by dot separated config names.
+// Items of lists share path of the list.
+type MergeRules map[string]MergeRule
+
+// Merger combines data of sources.
+type Merger interface {
+	// Merge merges src into dst.
+	Merge(dst, src map[string]interface{}) error
+}
+
+// RuledMerger is merger, that accepts merge directives of config struct.
+type RuledMerger interface {
+	Merger
+	WithRules(rules MergeRules) Merger
+}
+
+// DeepMerger merges maps recursively:
+//   - nested maps are merged key by key;
+//   - lists are combined according to the list strategy;
+//   - other values (and values of different types) are replaced.
+//
+// Strategy can be overridden for the key by rules (see RulesOf) or by marker in the source:
+//
+//	plugins:
+//	  $merge: append
+//	  $value: [metrics]
+//	routes:
+//	  $merge: bykey
+//	  $key: path
+//	  $value:
+//	    - path: /api
+//	      timeout: 5s
+//	database:
+//	  $merge: replace
+//	  dsn: postgres://localhost
+//
+// Marker has priority over rule. Values of src are copied, so sources are never modified.
+type DeepMerger struct {
+	lists MergeStrategy
+	rules MergeRules
+}
+
+func NewDeepMerger(lists MergeStrategy) *DeepMerger {
+	return &DeepMerger{
+		lists: lists,
+	}
+}
+
+func (that *DeepMerger) WithRules(rules MergeRules) Merger {
+	return &DeepMerger{
+		lists: that.lists,
+		rules: rules,
+	}
+}
+
+func (that *DeepMerger) Merge(dst, src map[string]interface{}) error {
+	return that.mergeMap("", dst, src)
+}
+
+func (that *DeepMerger) mergeMap(path string, dst, src map[string]interface{}) error {
+	for key, value := range src {
+		if isMergeMarker(key) {
+			continue
+		}
+
+		p := key
+		if path != "" {
+			p = path + "." + key
+		}
+
+		v, err := that.mergeValue(p, dst[key], value)
+		if err != nil {
+			return err
+		}
+		dst[key] = v
+	}
+	return nil
+}
+
+func (that *DeepMerger) mergeValue(path string, dst, src interface{}) (interface{}, error) {
+	rule, hasRule := that.rules[path]
+	src, marker, hasMarker, err := unwrapMergeMarker(src)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if hasMarker {
+		rule, hasRule = marker, true
+	}
+
+	switch s := src.(type) {
+	case map[string]interface{}:
+		d, ok := dst.(map[string]interface{})
+		if !ok || (hasRule && rule.Strategy == MergeReplace) {
+			d = make(map[string]interface{}, len(s))
+		}
+		return d, that.mergeMap(path, d, s)
+	case []interface{}:
+		items, err := that.cleanList(path, s)
+		if err != nil {
+			return nil, err
+		}
+
+		d, ok := dst.([]interface{})
+		if !ok {
+			return items, nil
+		}
+
+		if !hasRule {
+			rule = MergeRule{Strategy: that.lists}
+		}
+		return that.mergeList(path, rule, d, items)
+	}
+
+	return cloneData(src), nil
+}
+
+func (that *DeepMerger) mergeList(path string, rule MergeRule, dst, src []interface{}) (interface{}, error) {
+	switch rule.Strategy {
+	case MergeAppend:
+		return append(dst, src...), nil
+	case MergePrepend:
+		return append(src, dst...), nil
+	case MergeUnion:
+		for _, item := range src {
+			if !containsItem(dst, item) {
+				dst = append(dst, item)
+			}
+		}
+		return dst, nil
+	case MergeByKey:
+		key := rule.Key
+		if key == "" {
+			key = DefaultMergeKey
+		}
+
+		for _, item := range src {
+			index := indexOfItem(dst, key, item)
+			if index < 0 {
+				dst = append(dst, item)
+				continue
+			}
+
+			merged, err := that.mergeValue(path, dst[index], item)
+			if err != nil {
+				return nil, err
+			}
+			dst[index] = merged
+		}
+		return dst, nil
+	default:
+		return src, nil
+	}
+}
+
+// cleanList copies items of list without merge markers.
+func (that *DeepMerger) cleanList(path string, list []interface{}) ([]interface{}, error) {
+	res := make([]interface{}, len(list))
+	for i, item := range list {
+		v, err := that.mergeValue(path, nil, item)
+		if err != nil {
+			return nil, err
+		}
+		res[i] = v
+	}
+	return res, nil
+}
+
+func unwrapMergeMarker(value interface{}) (interface{}, MergeRule, bool, error) {
+	m, ok := value.(map[string]interface{})
+	if !ok {
+		return value, MergeRule{}, false, nil
+	}
+
+	raw, ok := m[MergeMarker]
+	if !ok {
+		return value, MergeRule{}, false, nil
+	}
+
+	name, _ := raw.(string)
+	strategy, err := MergeStrategies.Encode(name)
+	if err != nil {
+		return nil, MergeRule{}, false, fmt.Errorf("%w: %v", ErrInvalidMergeStrategy, raw)
+	}
+
+	rule := MergeRule{Strategy: strategy}
+	rule.Key, _ = m[MergeKeyMarker].(string)
+
+	if v, ok := m[MergeValueMarker]; ok {
+		return v, rule, true, nil
+	}
+
+	return value, rule, true, nil
+}
+
+func isMergeMarker(key string) bool {
+	return key == MergeMarker || key == MergeKeyMarker || key == MergeValueMarker
+}
+
+func containsItem(list []interface{}, item interface{}) bool {
+	for _, v := range list {
+		if reflect.DeepEqual(v, item) {
+			return true
+		}
+	}
+	return false
+}
+
+func indexOfItem(list []interface{}, key string, item interface{}) int {
+	m, ok := item.(map[string]interface{})
+	if !ok {
+		return -1
+	}
+
+	id, ok := m[key]
+	if !ok {
+		return -1
+	}
+
+	for i, v := range list {
+		if vm, ok := v.(map[string]interface{}); ok {
+			if vid, ok := vm[key]; ok && reflect.DeepEqual(vid, id) {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func cloneData(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		res := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			res[key] = cloneData(item)
+		}
+		return res
+	case []interface{}:
+		res := make([]interface{}, len(v))
+		for i, item := range v {
+			res[i] = cloneData(item)
+		}
+		return res
+	default:
+		return value
+	}
+}
+
+// RulesOf extracts merge directives from tags of config struct:
+//
+//	Plugins []string `config:"plugins,merge=union"`
+//	Routes  []Route  `config:"routes,merge=bykey,mergekey=path"`
+func RulesOf(config interface{}) (MergeRules, error) {
+	rules := make(MergeRules)
+	tp := reflect.TypeOf(config)
+	if err := collectRules(rules, "", tp, make(map[reflect.Type]bool)); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+func collectRules(rules MergeRules, path string, tp reflect.Type, visited map[reflect.Type]bool) error {
+	for tp != nil && (tp.Kind() == reflect.Ptr || tp.Kind() == reflect.Slice || tp.Kind() == reflect.Array) {
+		tp = tp.Elem()
+	}
+	if tp == nil || tp.Kind() != reflect.Struct || visited[tp] {
+		return nil
+	}
+
+	visited[tp] = true
+	defer delete(visited, tp)
+
+	for i := 0; i < tp.NumField(); i++ {
+		field := tp.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, ok := NameOf(field)
+		if !ok {
+			continue
+		}
+		if path != "" {
+			name = path + "." + name
+		}
+
+		tags := ParseTags(field.Tag.Get("config"))
+		if strategy, ok := tags["merge"]; ok {
+			s, err := MergeStrategies.Encode(strategy)
+			if err != nil {
+				return fmt.Errorf("%s: %w: %s", name, ErrInvalidMergeStrategy, strategy)
+			}
+			rules[name] = MergeRule{Strategy: s, Key: tags["mergekey"]}
+		}
+
+		if err := collectRules(rules, name, field.Type, visited); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+const (
+	MergeMarker      = "$merge"
+	MergeKeyMarker   = "$key"
+	MergeValueMarker = "$value"
+	DefaultMergeKey  = "name"
+)
+
+var (
+	DefaultMerger = NewDeepMerger(MergeReplace)
+)
+
+var (
+	ErrInvalidMergeStrategy = fmt.Errorf("invalid merge strategy")
+)
@@ -0,0 +1,148 @@
+package configs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeepMerger_Merge(t *testing.T) {
+	base := func() map[string]interface{} {
+		return map[string]interface{}{
+			"name":    "app",
+			"plugins": []interface{}{"auth"},
+			"db": map[string]interface{}{
+				"host": "localhost",
+				"port": 5432,
+			},
+		}
+	}
+	overlay := map[string]interface{}{
+		"plugins": []interface{}{"metrics"},
+		"db": map[string]interface{}{
+			"host": "db.prod",
+		},
+	}
+
+	tests := []struct {
+		name     string
+		lists    MergeStrategy
+		expected map[string]interface{}
+	}{
+		{
+			name:  "replace lists",
+			lists: MergeReplace,
+			expected: map[string]interface{}{
+				"name":    "app",
+				"plugins": []interface{}{"metrics"},
+				"db": map[string]interface{}{
+					"host": "db.prod",
+					"port": 5432,
+				},
+			},
+		},
+		{
+			name:  "append lists",
+			lists: MergeAppend,
+			expected: map[string]interface{}{
+				"name":    "app",
+				"plugins": []interface{}{"auth", "metrics"},
+				"db": map[string]interface{}{
+					"host": "db.prod",
+					"port": 5432,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := base()
+			data := make(map[string]interface{})
+			merger := NewDeepMerger(tt.lists)
+			require.NoError(t, merger.Merge(data, source))
+			require.NoError(t, merger.Merge(data, overlay))
+
+			assert.Equal(t, tt.expected, data)
+			assert.Equal(t, base(), source)
+		})
+	}
+}
+
+func TestDeepMerger_Markers(t *testing.T) {
+	data := map[string]interface{}{
+		"plugins": []interface{}{"auth", "cache"},
+		"filters": []interface{}{"b"},
+		"tags":    []interface{}{"x", "y"},
+		"routes": []interface{}{
+			map[string]interface{}{"path": "/api", "timeout": "1s", "auth": true},
+			map[string]interface{}{"path": "/health", "timeout": "1s"},
+		},
+		"database": map[string]interface{}{"host": "localhost", "port": 5432},
+	}
+
+	overlay := map[string]interface{}{
+		"plugins": map[string]interface{}{"$merge": "append", "$value": []interface{}{"metrics"}},
+		"filters": map[string]interface{}{"$merge": "prepend", "$value": []interface{}{"a"}},
+		"tags":    map[string]interface{}{"$merge": "union", "$value": []interface{}{"y", "z"}},
+		"routes": map[string]interface{}{
+			"$merge": "bykey",
+			"$key":   "path",
+			"$value": []interface{}{
+				map[string]interface{}{"path": "/api", "timeout": "5s"},
+				map[string]interface{}{"path": "/admin"},
+			},
+		},
+		"database": map[string]interface{}{"$merge": "replace", "dsn": "postgres://db"},
+	}
+
+	require.NoError(t, DefaultMerger.Merge(data, overlay))
+	assert.Equal(t, map[string]interface{}{
+		"plugins": []interface{}{"auth", "cache", "metrics"},
+		"filters": []interface{}{"a", "b"},
+		"tags":    []interface{}{"x", "y", "z"},
+		"routes": []interface{}{
+			map[string]interface{}{"path": "/api", "timeout": "5s", "auth": true},
+			map[string]interface{}{"path": "/health", "timeout": "1s"},
+			map[string]interface{}{"path": "/admin"},
+		},
+		"database": map[string]interface{}{"dsn": "postgres://db"},
+	}, data)
+
+	err := DefaultMerger.Merge(data, map[string]interface{}{
+		"tags": map[string]interface{}{"$merge": "unknown"},
+	})
+	assert.ErrorIs(t, err, ErrInvalidMergeStrategy)
+}
+
+func TestDeepMerger_Rules(t *testing.T) {
+	type route struct {
+		Path    string `config:"path"`
+		Timeout string `config:"timeout"`
+	}
+	type server struct {
+		Routes []route `config:"routes,merge=bykey,mergekey=path"`
+	}
+	type config struct {
+		Plugins []string `config:"plugins,merge=union"`
+		Servers []server `config:"servers"`
+	}
+
+	rules, err := RulesOf(&config{})
+	require.NoError(t, err)
+	assert.Equal(t, MergeRules{
+		"plugins":        {Strategy: MergeUnion},
+		"servers.routes": {Strategy: MergeByKey, Key: "path"},
+	}, rules)
+
+	merger := DefaultMerger.WithRules(rules)
+	data := map[string]interface{}{}
+	require.NoError(t, merger.Merge(data, map[string]interface{}{
+		"plugins": []interface{}{"auth"},
+	}))
+	require.NoError(t, merger.Merge(data, map[string]interface{}{
+		"plugins": []interface{}{"auth", "metrics"},
+	}))
+	assert.Equal(t, []interface{}{"auth", "metrics"}, data["plugins"])
+}
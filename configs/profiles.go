@@ -0,0 +1,39 @@
+package configs
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ProfilesFromEnv returns list of active profiles from environment variable
+// with comma separated values (for example APP_PROFILE=prod,eu).
+func ProfilesFromEnv(name string) []string {
+	return ParseProfiles(os.Getenv(name))
+}
+
+// ParseProfiles parses comma separated list of profiles.
+func ParseProfiles(value string) []string {
+	var profiles []string
+	for _, profile := range strings.Split(value, ",") {
+		profile = strings.TrimSpace(profile)
+		if profile != "" {
+			profiles = append(profiles, profile)
+		}
+	}
+	return profiles
+}
+
+// ProfileFiles returns base file and its overlays for profiles in order of applying.
+func ProfileFiles(file string, profiles ...string) []string {
+	ext := filepath.Ext(file)
+	name := strings.TrimSuffix(file, ext)
+
+	files := make([]string, 0, len(profiles)+1)
+	files = append(files, file)
+	for _, profile := range profiles {
+		name += "." + profile
+		files = append(files, name+ext)
+	}
+	return files
+}
@@ -0,0 +1,49 @@
+package configs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProfileFiles(t *testing.T) {
+	assert.Equal(t,
+		[]string{"config.yaml", "config.prod.yaml", "config.prod.eu.yaml"},
+		ProfileFiles("config.yaml", ParseProfiles(" prod, ,eu ")...),
+	)
+	assert.Equal(t, []string{"config.yaml"}, ProfileFiles("config.yaml", ParseProfiles("")...))
+}
+
+func TestFileLoaderBuilder_WithProfiles(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"config.yaml":         "name: app\nplugins: [auth]\naddress:\n  host: localhost\n  port: 80\n",
+		"config.prod.yaml":    "plugins: [metrics]\naddress:\n  host: prod.example.com\n",
+		"config.prod.eu.yaml": "address:\n  port: 8080\n",
+	}
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+
+	t.Setenv("MYAPP_TEST_PROFILE", "prod,eu,local")
+
+	loader, err := NewFileLoaderBuilder().
+		WithProfiles(filepath.Join(dir, "config.yaml"), true, ProfilesFromEnv("MYAPP_TEST_PROFILE")...).
+		WithMerger(NewDeepMerger(MergeAppend)).
+		Build()
+	require.NoError(t, err)
+
+	var config struct {
+		Name    string      `config:"name"`
+		Plugins []string    `config:"plugins"`
+		Address heldAddress `config:"address"`
+	}
+	require.NoError(t, loader.Load(&config))
+
+	assert.Equal(t, "app", config.Name)
+	assert.Equal(t, []string{"auth", "metrics"}, config.Plugins)
+	assert.Equal(t, heldAddress{Host: "prod.example.com", Port: 8080}, config.Address)
+}
@@ -0,0 +1,60 @@
+package configs
+
+import (
+	"mime"
+	"net/url"
+
+	memoryFetcher "github.com/adverax/metacrm.kernel/access/fetchers/bytes/memory"
+)
+
+// RemoteFetcher is fetcher of config service (see httpFetcher.Fetcher).
+type RemoteFetcher interface {
+	Fetcher
+	Url() string
+	ContentType() string
+}
+
+// RemoteSource is source of config service. Format of content is detected
+// by content type of response, then by extension of url. Json is used by default.
+type RemoteSource struct {
+	fetcher RemoteFetcher
+}
+
+func NewRemoteSource(fetcher RemoteFetcher) *RemoteSource {
+	return &RemoteSource{
+		fetcher: fetcher,
+	}
+}
+
+func (that *RemoteSource) Fetch() (map[string]interface{}, error) {
+	data, err := that.fetcher.Fetch()
+	if err != nil {
+		return nil, err
+	}
+
+	return that.builder()(memoryFetcher.New(data)).Fetch()
+}
+
+func (that *RemoteSource) builder() SourceBuilder {
+	if mediaType, _, err := mime.ParseMediaType(that.fetcher.ContentType()); err == nil {
+		if builder, ok := mediaTypes[mediaType]; ok {
+			return builder
+		}
+	}
+
+	if u, err := url.Parse(that.fetcher.Url()); err == nil {
+		if builder, err := formats.Detect(u.Path); err == nil {
+			return builder
+		}
+	}
+
+	return newJsonSource
+}
+
+var mediaTypes = map[string]SourceBuilder{
+	"application/json":   newJsonSource,
+	"application/yaml":   newYamlSource,
+	"application/x-yaml": newYamlSource,
+	"text/yaml":          newYamlSource,
+	"application/toml":   newTomlSource,
+}
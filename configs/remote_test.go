@@ -0,0 +1,44 @@
+package configs
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	httpFetcher "github.com/adverax/metacrm.kernel/access/fetchers/bytes/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemoteSource(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/config":
+			w.Header().Set("Content-Type", "application/yaml; charset=utf-8")
+			_, _ = w.Write([]byte("address:\n  port: 8080\n"))
+		case "/config.json":
+			_, _ = w.Write([]byte(`{"address":{"port":8081}}`))
+		}
+	}))
+	defer server.Close()
+
+	tests := map[string]interface{}{
+		"/config":      8080,
+		"/config.json": float64(8081),
+	}
+
+	for path, expected := range tests {
+		t.Run(path, func(t *testing.T) {
+			fetcher, err := httpFetcher.NewBuilder().
+				WithUrl(server.URL + path).
+				Build()
+			require.NoError(t, err)
+
+			data, err := NewRemoteSource(fetcher).Fetch()
+			require.NoError(t, err)
+			assert.Equal(t, map[string]interface{}{
+				"address": map[string]interface{}{"port": expected},
+			}, data)
+		})
+	}
+}
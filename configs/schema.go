@@ -0,0 +1,385 @@
+package configs
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schema is JSON Schema of config.
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Default              interface{}        `json:"default,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+}
+
+// SchemaOf generates schema of config struct.
+// Tags of fields are used:
+//
+//	Port int `config:"port,required,default=8080" doc:"Port of the server"`
+func SchemaOf(config interface{}) (*Schema, error) {
+	schema, err := schemaOfType(reflect.TypeOf(config), make(map[reflect.Type]bool))
+	if err != nil {
+		return nil, err
+	}
+
+	schema.Schema = SchemaDraft
+	return schema, nil
+}
+
+func schemaOfType(tp reflect.Type, visited map[reflect.Type]bool) (*Schema, error) {
+	for tp.Kind() == reflect.Ptr {
+		tp = tp.Elem()
+	}
+
+	switch tp {
+	case durationType:
+		return &Schema{Type: "string", Format: "duration"}, nil
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}, nil
+	}
+
+	switch tp.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}, nil
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}, nil
+	case reflect.String:
+		return &Schema{Type: "string"}, nil
+	case reflect.Slice, reflect.Array:
+		if tp.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string"}, nil
+		}
+		items, err := schemaOfType(tp.Elem(), visited)
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: "array", Items: items}, nil
+	case reflect.Map:
+		items, err := schemaOfType(tp.Elem(), visited)
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: "object", AdditionalProperties: items}, nil
+	case reflect.Interface:
+		// Typed values (Integer, String, ...) are described by result of the method Get
+		if method, ok := tp.MethodByName("Get"); ok && method.Type.NumOut() != 0 {
+			return schemaOfType(method.Type.Out(0), visited)
+		}
+		return &Schema{}, nil
+	case reflect.Struct:
+		return schemaOfStruct(tp, visited)
+	}
+
+	return &Schema{}, nil
+}
+
+func schemaOfStruct(tp reflect.Type, visited map[reflect.Type]bool) (*Schema, error) {
+	schema := &Schema{Type: "object"}
+	if visited[tp] {
+		return schema, nil
+	}
+
+	visited[tp] = true
+	defer delete(visited, tp)
+
+	for _, field := range fieldsOf(tp) {
+		name := field.Name
+		prop, err := schemaOfType(field.Type, visited)
+		if err != nil {
+			return nil, err
+		}
+
+		prop.Description = field.Tag.Get("doc")
+
+		tags := ParseTags(field.Tag.Get("config"))
+		if _, ok := tags["required"]; ok {
+			schema.Required = append(schema.Required, name)
+		}
+		if def, ok := tags["default"]; ok {
+			prop.Default, err = parseDefault(prop, def)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+		}
+
+		if schema.Properties == nil {
+			schema.Properties = make(map[string]*Schema)
+		}
+		schema.Properties[name] = prop
+	}
+
+	return schema, nil
+}
+
+func parseDefault(schema *Schema, value string) (interface{}, error) {
+	switch schema.Type {
+	case "boolean":
+		return strconv.ParseBool(value)
+	case "integer":
+		return strconv.ParseInt(value, 10, 64)
+	case "number":
+		return strconv.ParseFloat(value, 64)
+	}
+	return value, nil
+}
+
+// Validate checks types and required fields of data.
+func (that *Schema) Validate(data map[string]interface{}) error {
+	var errs ValidationErrors
+	that.validateType(&errs, nil, data, nil)
+	that.validateRequired(&errs, nil, data)
+	return errs.Err()
+}
+
+// validateType checks types of values. Absent keys are not checked,
+// so partial data of the single source is accepted.
+func (that *Schema) validateType(errs *ValidationErrors, path []string, value interface{}, locator Locator) {
+	if value == nil {
+		return
+	}
+
+	value, _, _, err := unwrapMergeMarker(value)
+	if err != nil {
+		errs.Add(locator, path, err.Error())
+		return
+	}
+
+	if !that.accepts(value) {
+		errs.Add(locator, path, fmt.Sprintf("expected %s, got %s", that.Type, kindOf(value)))
+		return
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, key := range sortedKeys(v) {
+			if isMergeMarker(key) {
+				continue
+			}
+
+			item := that.AdditionalProperties
+			if that.Properties != nil {
+				item = that.Properties[key]
+			}
+			if item != nil {
+				item.validateType(errs, appendPath(path, key), v[key], locator)
+			}
+		}
+	case []interface{}:
+		if that.Items != nil {
+			for i, item := range v {
+				that.Items.validateType(errs, appendPath(path, strconv.Itoa(i)), item, locator)
+			}
+		}
+	}
+}
+
+// validateRequired checks presence of required keys in the merged data.
+func (that *Schema) validateRequired(errs *ValidationErrors, path []string, value interface{}) {
+	value, _, _, _ = unwrapMergeMarker(value)
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, key := range that.Required {
+			if _, ok := v[key]; !ok {
+				errs.Add(nil, appendPath(path, key), "value is required")
+			}
+		}
+		for key, prop := range that.Properties {
+			if item, ok := v[key]; ok {
+				prop.validateRequired(errs, appendPath(path, key), item)
+			}
+		}
+	case []interface{}:
+		if that.Items != nil {
+			for i, item := range v {
+				that.Items.validateRequired(errs, appendPath(path, strconv.Itoa(i)), item)
+			}
+		}
+	}
+}
+
+func (that *Schema) accepts(value interface{}) bool {
+	switch that.Type {
+	case "object":
+		_, ok := value.(map[string]interface{})
+		return ok
+	case "array":
+		_, ok := value.([]interface{})
+		return ok
+	case "boolean":
+		switch v := value.(type) {
+		case bool:
+			return true
+		case string:
+			_, err := strconv.ParseBool(v)
+			return err == nil
+		}
+		return false
+	case "integer":
+		switch v := value.(type) {
+		case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+			return true
+		case float32:
+			return float32(int64(v)) == v
+		case float64:
+			return float64(int64(v)) == v
+		case string:
+			_, err := strconv.ParseInt(v, 10, 64)
+			return err == nil
+		}
+		return false
+	case "number":
+		switch v := value.(type) {
+		case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+			return true
+		case string:
+			_, err := strconv.ParseFloat(v, 64)
+			return err == nil
+		}
+		return false
+	case "string":
+		switch value.(type) {
+		case string:
+			return true
+		case time.Time:
+			return that.Format == "date-time"
+		}
+		switch reflect.ValueOf(value).Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+			return that.Format == "duration"
+		}
+		return false
+	}
+
+	return true
+}
+
+func kindOf(value interface{}) string {
+	switch value.(type) {
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case bool:
+		return "boolean"
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return "integer"
+	case float32, float64:
+		return "number"
+	case string:
+		return "string"
+	}
+	return reflect.TypeOf(value).String()
+}
+
+func appendPath(path []string, key string) []string {
+	return append(path[:len(path):len(path)], key)
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Locator is source, that knows positions of keys in the origin file.
+type Locator interface {
+	Locate(path []string) (file string, line, column int, ok bool)
+}
+
+// ValidationError describes invalid value of config.
+type ValidationError struct {
+	File    string
+	Line    int
+	Column  int
+	Path    string
+	Message string
+}
+
+func (that *ValidationError) Error() string {
+	var b strings.Builder
+	if that.File != "" {
+		b.WriteString(that.File)
+		b.WriteString(":")
+	}
+	if that.Line != 0 {
+		_, _ = fmt.Fprintf(&b, "%d:%d:", that.Line, that.Column)
+	}
+	if b.Len() != 0 {
+		b.WriteString(" ")
+	}
+	_, _ = fmt.Fprintf(&b, "%s: %s", that.Path, that.Message)
+	return b.String()
+}
+
+func (that *ValidationError) Unwrap() error {
+	return ErrValidation
+}
+
+// ValidationErrors is list of validation errors.
+type ValidationErrors []*ValidationError
+
+func (that *ValidationErrors) Add(locator Locator, path []string, message string) {
+	err := &ValidationError{
+		Path:    strings.Join(path, "."),
+		Message: message,
+	}
+	if locator != nil {
+		err.File, err.Line, err.Column, _ = locator.Locate(path)
+	}
+	*that = append(*that, err)
+}
+
+// Err returns nil for empty list.
+func (that ValidationErrors) Err() error {
+	if len(that) == 0 {
+		return nil
+	}
+	return that
+}
+
+func (that ValidationErrors) Error() string {
+	messages := make([]string, len(that))
+	for i, err := range that {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "\n")
+}
+
+func (that ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(that))
+	for i, err := range that {
+		errs[i] = err
+	}
+	return errs
+}
+
+const SchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+	timeType     = reflect.TypeOf(time.Time{})
+)
+
+var (
+	ErrValidation = errors.New("invalid config")
+)
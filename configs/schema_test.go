@@ -0,0 +1,140 @@
+package configs
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type schemaTestServer struct {
+	Host    string        `config:"host,required" doc:"Host of the server"`
+	Port    int           `config:"port,default=8080"`
+	Timeout time.Duration `config:"timeout"`
+}
+
+type schemaTestConfig struct {
+	Server  schemaTestServer   `config:"server,required"`
+	Debug   bool               `config:"debug"`
+	Tags    []string           `config:"tags"`
+	Limits  map[string]int     `config:"limits"`
+	Level   Integer            `config:"level"`
+	Servers []schemaTestServer `config:"servers"`
+}
+
+func TestSchemaOf(t *testing.T) {
+	schema, err := SchemaOf(&schemaTestConfig{})
+	require.NoError(t, err)
+
+	actual, err := json.Marshal(schema.Properties["server"])
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "object",
+		"properties": {
+			"host": {"type": "string", "description": "Host of the server"},
+			"port": {"type": "integer", "default": 8080},
+			"timeout": {"type": "string", "format": "duration"}
+		},
+		"required": ["host"]
+	}`, string(actual))
+
+	assert.Equal(t, SchemaDraft, schema.Schema)
+	assert.Equal(t, []string{"server"}, schema.Required)
+	assert.Equal(t, "array", schema.Properties["tags"].Type)
+	assert.Equal(t, "integer", schema.Properties["limits"].AdditionalProperties.Type)
+	assert.Equal(t, "integer", schema.Properties["level"].Type)
+}
+
+func TestSchema_Validate(t *testing.T) {
+	schema, err := SchemaOf(&schemaTestConfig{})
+	require.NoError(t, err)
+
+	err = schema.Validate(map[string]interface{}{
+		"server": map[string]interface{}{"host": "localhost", "port": "8080"},
+		"debug":  "true",
+	})
+	assert.NoError(t, err)
+
+	err = schema.Validate(map[string]interface{}{
+		"server": map[string]interface{}{"port": "http"},
+		"tags":   "a,b",
+	})
+	var errs ValidationErrors
+	require.ErrorAs(t, err, &errs)
+	assert.ErrorIs(t, err, ErrValidation)
+	assert.Equal(t, []string{"server.port", "tags", "server.host"}, pathsOf(errs))
+}
+
+func TestLoader_WithSchema(t *testing.T) {
+	dir := t.TempDir()
+	yamlFile := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(yamlFile, []byte("server:\n  host: localhost\n  port: http\n"), 0644))
+	jsonFile := filepath.Join(dir, "config.json")
+	require.NoError(t, os.WriteFile(jsonFile, []byte("{\n  \"servers\": [\n    {\"port\": true}\n  ]\n}\n"), 0644))
+
+	schema, err := SchemaOf(&schemaTestConfig{})
+	require.NoError(t, err)
+
+	loader, err := NewFileLoaderBuilder().
+		WithFile(yamlFile, true).
+		WithFile(jsonFile, true).
+		WithSchema(schema).
+		Build()
+	require.NoError(t, err)
+
+	err = loader.Load(&schemaTestConfig{})
+	var errs ValidationErrors
+	require.ErrorAs(t, err, &errs)
+	require.Len(t, errs, 2)
+	assert.Equal(t, yamlFile+":3:3: server.port: expected integer, got string", errs[0].Error())
+	assert.Equal(t, jsonFile+":3:6: servers.0.port: expected integer, got boolean", errs[1].Error())
+
+	require.NoError(t, os.WriteFile(yamlFile, []byte("server:\n  port: 80\n"), 0644))
+	require.NoError(t, os.WriteFile(jsonFile, []byte("{}"), 0644))
+	err = loader.Load(&schemaTestConfig{})
+	require.ErrorAs(t, err, &errs)
+	assert.Equal(t, []string{"server.host"}, pathsOf(errs))
+}
+
+func pathsOf(errs ValidationErrors) []string {
+	paths := make([]string, len(errs))
+	for i, err := range errs {
+		paths[i] = err.Path
+	}
+	return paths
+}
+
+func TestSchemaOf_Embedded(t *testing.T) {
+	type config struct {
+		BaseConfig
+		schemaTestServer
+		Name string `config:"name"`
+	}
+
+	schema, err := SchemaOf(&config{})
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+	assert.ElementsMatch(t, []string{"host", "port", "timeout", "name"}, names)
+	assert.Equal(t, []string{"host"}, schema.Required)
+}
+
+func TestSchema_ValidateDuration(t *testing.T) {
+	schema, err := SchemaOf(&schemaTestServer{})
+	require.NoError(t, err)
+
+	for _, timeout := range []interface{}{"5s", 5000, float64(5000), 5 * time.Second} {
+		err := schema.Validate(map[string]interface{}{"host": "localhost", "timeout": timeout})
+		assert.NoError(t, err, timeout)
+	}
+
+	err = schema.Validate(map[string]interface{}{"host": "localhost", "timeout": true})
+	assert.ErrorIs(t, err, ErrValidation)
+}
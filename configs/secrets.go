@@ -0,0 +1,247 @@
+package configs
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/adverax/metacrm.kernel/log"
+)
+
+// Cipher encrypts and decrypts secret values by AES-GCM.
+// Encrypted value has format ENC[base64(nonce + ciphertext)].
+type Cipher struct {
+	aead cipher.AEAD
+}
+
+// NewCipher makes cipher with raw key of 16, 24 or 32 bytes.
+func NewCipher(key []byte) (*Cipher, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSecretKey, err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Cipher{aead: aead}, nil
+}
+
+// NewCipherFromFile makes cipher with key, stored in the file (see ParseKey).
+func NewCipherFromFile(filename string) (*Cipher, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("error read secret key: %w", err)
+	}
+
+	key, err := ParseKey(string(data))
+	if err != nil {
+		return nil, err
+	}
+
+	return NewCipher(key)
+}
+
+// NewCipherFromEnv makes cipher with key, stored in the environment variable (see ParseKey).
+func NewCipherFromEnv(name string) (*Cipher, error) {
+	text, ok := os.LookupEnv(name)
+	if !ok {
+		return nil, fmt.Errorf("%w: variable %s is not defined", ErrInvalidSecretKey, name)
+	}
+
+	key, err := ParseKey(text)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewCipher(key)
+}
+
+// Encrypt encrypts plain text into the value ENC[...].
+func (that *Cipher) Encrypt(plain string) (string, error) {
+	nonce := make([]byte, that.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	sealed := that.aead.Seal(nonce, nonce, []byte(plain), nil)
+	return encryptedPrefix + base64.StdEncoding.EncodeToString(sealed) + encryptedSuffix, nil
+}
+
+// Decrypt decrypts value ENC[...] into plain text.
+func (that *Cipher) Decrypt(value string) (string, error) {
+	if !IsEncrypted(value) {
+		return "", fmt.Errorf("%w: value is not encrypted", ErrDecryption)
+	}
+
+	raw := value[len(encryptedPrefix) : len(value)-len(encryptedSuffix)]
+	data, err := base64.StdEncoding.DecodeString(raw)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrDecryption, err)
+	}
+
+	size := that.aead.NonceSize()
+	if len(data) < size {
+		return "", fmt.Errorf("%w: value is too short", ErrDecryption)
+	}
+
+	plain, err := that.aead.Open(nil, data[:size], data[size:], nil)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrDecryption, err)
+	}
+
+	return string(plain), nil
+}
+
+// IsEncrypted checks if value has format ENC[...].
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, encryptedPrefix) && strings.HasSuffix(value, encryptedSuffix)
+}
+
+// GenerateKey generates random key for AES-256.
+func GenerateKey() ([]byte, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// EncodeKey encodes key into text, suitable for ParseKey.
+func EncodeKey(key []byte) string {
+	return base64.StdEncoding.EncodeToString(key)
+}
+
+// ParseKey decodes key from base64 or hex text. Surrounding spaces are ignored.
+func ParseKey(text string) ([]byte, error) {
+	text = strings.TrimSpace(text)
+
+	if key, err := base64.StdEncoding.DecodeString(text); err == nil && isValidKeySize(len(key)) {
+		return key, nil
+	}
+
+	if key, err := hex.DecodeString(text); err == nil && isValidKeySize(len(key)) {
+		return key, nil
+	}
+
+	return nil, fmt.Errorf("%w: expected base64 or hex encoded key of 16, 24 or 32 bytes", ErrInvalidSecretKey)
+}
+
+func isValidKeySize(size int) bool {
+	return size == 16 || size == 24 || size == 32
+}
+
+// DecryptedSource is decorator of the Source, that decrypts values ENC[...].
+// Names of decrypted keys are collected for masking in logs.
+type DecryptedSource struct {
+	mx      sync.Mutex
+	source  Source
+	cipher  *Cipher
+	secrets map[string]bool
+}
+
+func NewDecryptedSource(source Source, cipher *Cipher) *DecryptedSource {
+	return &DecryptedSource{
+		source:  source,
+		cipher:  cipher,
+		secrets: make(map[string]bool),
+	}
+}
+
+// Locate delegates to the decorated source.
+func (that *DecryptedSource) Locate(path []string) (file string, line, column int, ok bool) {
+	if locator, ok := that.source.(Locator); ok {
+		return locator.Locate(path)
+	}
+	return "", 0, 0, false
+}
+
+func (that *DecryptedSource) Fetch() (map[string]interface{}, error) {
+	data, err := that.source.Fetch()
+	if err != nil {
+		return nil, err
+	}
+
+	if data == nil {
+		return nil, nil
+	}
+
+	that.mx.Lock()
+	defer that.mx.Unlock()
+
+	res, err := that.decrypt("", data)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.(map[string]interface{}), nil
+}
+
+// Secrets returns maskers for names of decrypted keys.
+// Result can be passed into log.NewSecurityExporter.
+func (that *DecryptedSource) Secrets() map[string]log.Masker {
+	that.mx.Lock()
+	defer that.mx.Unlock()
+
+	res := make(map[string]log.Masker, len(that.secrets))
+	for key := range that.secrets {
+		res[key] = log.MaskAny
+	}
+	return res
+}
+
+func (that *DecryptedSource) decrypt(key string, value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		if !IsEncrypted(v) {
+			return v, nil
+		}
+		plain, err := that.cipher.Decrypt(v)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+		that.secrets[key] = true
+		return plain, nil
+	case map[string]interface{}:
+		res := make(map[string]interface{}, len(v))
+		for k, item := range v {
+			val, err := that.decrypt(k, item)
+			if err != nil {
+				return nil, err
+			}
+			res[k] = val
+		}
+		return res, nil
+	case []interface{}:
+		res := make([]interface{}, len(v))
+		for i, item := range v {
+			val, err := that.decrypt(key, item)
+			if err != nil {
+				return nil, err
+			}
+			res[i] = val
+		}
+		return res, nil
+	default:
+		return value, nil
+	}
+}
+
+const (
+	encryptedPrefix = "ENC["
+	encryptedSuffix = "]"
+)
+
+var (
+	ErrInvalidSecretKey = errors.New("invalid secret key")
+	ErrDecryption       = errors.New("decryption error")
+)
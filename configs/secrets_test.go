@@ -0,0 +1,68 @@
+package configs
+
+import (
+	"testing"
+
+	"github.com/adverax/metacrm.kernel/access/fetchers/maps/maps"
+	"github.com/adverax/metacrm.kernel/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCipher(t *testing.T) {
+	key, err := GenerateKey()
+	require.NoError(t, err)
+
+	parsed, err := ParseKey(" " + EncodeKey(key) + "\n")
+	require.NoError(t, err)
+	assert.Equal(t, key, parsed)
+
+	cipher, err := NewCipher(key)
+	require.NoError(t, err)
+
+	encrypted, err := cipher.Encrypt("s3cr3t")
+	require.NoError(t, err)
+	assert.True(t, IsEncrypted(encrypted))
+
+	plain, err := cipher.Decrypt(encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, "s3cr3t", plain)
+
+	other, err := NewCipher(make([]byte, 32))
+	require.NoError(t, err)
+	_, err = other.Decrypt(encrypted)
+	assert.ErrorIs(t, err, ErrDecryption)
+
+	_, err = ParseKey("short")
+	assert.ErrorIs(t, err, ErrInvalidSecretKey)
+}
+
+func TestDecryptedSource(t *testing.T) {
+	key, err := GenerateKey()
+	require.NoError(t, err)
+	cipher, err := NewCipher(key)
+	require.NoError(t, err)
+
+	password, err := cipher.Encrypt("s3cr3t")
+	require.NoError(t, err)
+
+	source := NewDecryptedSource(maps.Engine{
+		"database": map[string]interface{}{
+			"user":     "admin",
+			"password": password,
+		},
+	}, cipher)
+
+	data, err := source.Fetch()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"database": map[string]interface{}{
+			"user":     "admin",
+			"password": "s3cr3t",
+		},
+	}, data)
+
+	fields := log.Fields{"password": "s3cr3t", "user": "admin"}
+	log.MaskAll(source.Secrets(), fields)
+	assert.Equal(t, log.Fields{"password": "****", "user": "admin"}, fields)
+}
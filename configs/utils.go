@@ -5,6 +5,7 @@ import (
 	"crypto/md5"
 	"encoding/json"
 	"fmt"
+	"github.com/adverax/metacrm.kernel/access/paths"
 	"github.com/adverax/metacrm.kernel/types/convert"
 	"reflect"
 	"strings"
@@ -34,12 +35,7 @@ func LetTyped[T any](ctx context.Context, dst interface{}, src interface{}) erro
 	}
 
 	if d, ok := dst.(Letter[T]); ok {
-		val := reflect.ValueOf(src)
-		tp := val.Type()
-		if val.Type().ConvertibleTo(tp) {
-			var v T
-			value := val.Convert(tp)
-			reflect.ValueOf(&v).Elem().Set(value)
+		if v, ok := convert.As[T](src); ok {
 			return d.Let(ctx, v)
 		}
 	}
@@ -50,75 +46,95 @@ func LetTyped[T any](ctx context.Context, dst interface{}, src interface{}) erro
 // Assign assigns values from src to dst.
 func Assign(ctx context.Context, dst interface{}, src map[string]interface{}) error {
 	dstValue := reflect.ValueOf(dst).Elem()
-	dstType := dstValue.Type()
 
-	for i := 0; i < dstValue.NumField(); i++ {
-		field := dstValue.Field(i)
-		fieldType := dstType.Field(i)
-
-		if !fieldType.IsExported() {
-			continue
-		}
+	for _, fieldType := range fieldsOf(dstValue.Type()) {
+		field := dstValue.FieldByIndex(fieldType.Index)
 		if !field.CanSet() {
 			continue
 		}
 
-		raw := fieldType.Tag.Get("config")
-		if raw == "-" {
-			continue
+		if value, ok := src[fieldType.Name]; ok {
+			err := assignValue(ctx, field, value)
+			if err != nil {
+				return err
+			}
 		}
+	}
+	return nil
+}
 
-		tags := ParseTags(raw)
-
-		name := strings.ToLower(fieldType.Name)
-		if tag, ok := tags["name"]; ok {
-			name = tag
+func assignValue(ctx context.Context, field reflect.Value, value interface{}) error {
+	switch field.Kind() {
+	case reflect.Interface:
+		if field.IsNil() {
+			if value != nil && reflect.TypeOf(value).AssignableTo(field.Type()) {
+				field.Set(reflect.ValueOf(value))
+			}
+			return nil
 		}
-
-		if value, ok := src[name]; ok {
-			kind := field.Kind()
-			switch kind {
-			case reflect.Interface:
-				err := Let(ctx, field.Interface(), value)
+		return Let(ctx, field.Interface(), value)
+	case reflect.Struct:
+		if val, ok := value.(map[string]interface{}); ok {
+			return Assign(ctx, field.Addr().Interface(), val)
+		}
+	case reflect.Slice:
+		if list, ok := value.([]interface{}); ok && field.Type().Elem().Kind() != reflect.Uint8 {
+			res := reflect.MakeSlice(field.Type(), len(list), len(list))
+			for i, item := range list {
+				err := assignValue(ctx, res.Index(i), item)
 				if err != nil {
 					return err
 				}
-			case reflect.Struct:
-				if val, ok := value.(map[string]interface{}); ok {
-					err := Assign(ctx, field.Addr().Interface(), val)
-					if err != nil {
-						return err
-					}
+			}
+			field.Set(res)
+			return nil
+		}
+	case reflect.Map:
+		if dict, ok := value.(map[string]interface{}); ok && field.Type().Key().Kind() == reflect.String {
+			res := reflect.MakeMapWithSize(field.Type(), len(dict))
+			for key, item := range dict {
+				val := reflect.New(field.Type().Elem()).Elem()
+				err := assignValue(ctx, val, item)
+				if err != nil {
+					return err
 				}
-			default:
-				if v, ok := convert.To(value, field.Type()); ok {
-					field.Set(v)
+				res.SetMapIndex(reflect.ValueOf(key).Convert(field.Type().Key()), val)
+			}
+			field.Set(res)
+			return nil
+		}
+	case reflect.Ptr:
+		if value != nil && field.Type().Elem().Kind() == reflect.Struct {
+			if _, ok := value.(map[string]interface{}); ok {
+				if field.IsNil() {
+					field.Set(reflect.New(field.Type().Elem()))
 				}
+				return assignValue(ctx, field.Elem(), value)
 			}
 		}
 	}
-	return nil
-}
 
-func override(a, b map[string]interface{}) {
-	for k, v := range b {
-		if av, ok := a[k]; ok {
-			if reflect.TypeOf(v) == reflect.TypeOf(av) {
-				switch v.(type) {
-				case map[string]interface{}:
-					override(av.(map[string]interface{}), v.(map[string]interface{}))
-				case []interface{}:
-					a[k] = v
-				default:
-					a[k] = v
-				}
-			} else {
-				a[k] = v
-			}
-		} else {
-			a[k] = v
+	if v, ok := convert.To(value, field.Type()); ok {
+		field.Set(v)
+	} else if s, ok := value.(string); ok {
+		// Values of env and dotenv sources are always strings
+		if err := convert.ConvertAssign(field.Addr().Interface(), s); err != nil {
+			return fmt.Errorf("error convert value %q into %s: %w", s, field.Type(), err)
 		}
 	}
+	return nil
+}
+
+// NameOf returns config name of the struct field.
+// Returns false, when field is excluded by tag `config:"-"`.
+func NameOf(field reflect.StructField) (string, bool) {
+	return paths.FieldName(field, "config")
+}
+
+// fieldsOf returns config fields of struct type. Fields of embedded structs are flattened (as in json),
+// fields without exported data (for example sync.RWMutex of BaseConfig) are skipped.
+func fieldsOf(tp reflect.Type) []paths.Field {
+	return paths.FieldsOf(tp, "config")
 }
 
 func hashOf(data map[string]interface{}) string {
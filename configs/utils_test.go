@@ -1,8 +1,12 @@
 package configs
 
 import (
+	"context"
 	"reflect"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestOverride(t *testing.T) {
@@ -46,9 +50,108 @@ func TestOverride(t *testing.T) {
 	}
 
 	for _, tt := range tests {
-		override(tt.a, tt.b)
+		require.NoError(t, DefaultMerger.Merge(tt.a, tt.b))
 		if !reflect.DeepEqual(tt.a, tt.expected) {
-			t.Errorf("override() = %v, want %v", tt.a, tt.expected)
+			t.Errorf("Merge() = %v, want %v", tt.a, tt.expected)
 		}
 	}
+
+	err := DefaultMerger.Merge(
+		map[string]interface{}{"key1": []interface{}{1}},
+		map[string]interface{}{"key1": map[string]interface{}{MergeMarker: "unknown", "items": []interface{}{2}}},
+	)
+	assert.ErrorIs(t, err, ErrInvalidMergeStrategy)
+}
+
+func TestAssign(t *testing.T) {
+	type server struct {
+		Host string `config:"host"`
+		Port int    `config:"port"`
+	}
+
+	type config struct {
+		Servers []server          `config:"servers"`
+		Tags    []string          `config:"tags"`
+		Limits  map[string]int    `config:"limits"`
+		Labels  map[string]string `config:"labels"`
+		Primary *server           `config:"primary"`
+		Backup  *server           `config:"backup"`
+		Raw     []byte            `config:"raw"`
+		Timeout int               `config:"timeout"`
+	}
+
+	dst := &config{
+		Tags: []string{"old"},
+	}
+	err := Assign(context.Background(), dst, map[string]interface{}{
+		"servers": []interface{}{
+			map[string]interface{}{"host": "a", "port": 80},
+			map[string]interface{}{"host": "b", "port": "81"},
+		},
+		"tags":    []interface{}{"x", "y"},
+		"limits":  map[string]interface{}{"cpu": 2, "mem": "512"},
+		"labels":  map[string]interface{}{"env": "prod"},
+		"primary": map[string]interface{}{"host": "c", "port": 82},
+		"backup":  nil,
+		"raw":     []byte("data"),
+		"timeout": "30",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, &config{
+		Servers: []server{{Host: "a", Port: 80}, {Host: "b", Port: 81}},
+		Tags:    []string{"x", "y"},
+		Limits:  map[string]int{"cpu": 2, "mem": 512},
+		Labels:  map[string]string{"env": "prod"},
+		Primary: &server{Host: "c", Port: 82},
+		Raw:     []byte("data"),
+		Timeout: 30,
+	}, dst)
+}
+
+func TestAssign_KeepsExistingPointer(t *testing.T) {
+	type server struct {
+		Host string `config:"host"`
+		Port int    `config:"port"`
+	}
+
+	type config struct {
+		Primary *server `config:"primary"`
+	}
+
+	primary := &server{Host: "a", Port: 80}
+	dst := &config{Primary: primary}
+	err := Assign(context.Background(), dst, map[string]interface{}{
+		"primary": map[string]interface{}{"port": 81},
+	})
+	require.NoError(t, err)
+
+	assert.Same(t, primary, dst.Primary)
+	assert.Equal(t, server{Host: "a", Port: 81}, *primary)
+}
+
+func TestAssign_InvalidString(t *testing.T) {
+	type config struct {
+		Port int `config:"port"`
+	}
+
+	err := Assign(context.Background(), &config{}, map[string]interface{}{"port": "abc"})
+	assert.Error(t, err)
+}
+
+func TestAssign_Embedded(t *testing.T) {
+	type common struct {
+		Name string `config:"name"`
+	}
+	type config struct {
+		BaseConfig
+		common
+		Port int `config:"port"`
+	}
+
+	var c config
+	err := Assign(context.Background(), &c, map[string]interface{}{"name": "app", "port": 80})
+	require.NoError(t, err)
+	assert.Equal(t, "app", c.Name)
+	assert.Equal(t, 80, c.Port)
 }
@@ -0,0 +1,285 @@
+package configs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// Watcher reloads configuration, when files behind the loader are changed.
+// Each reload is performed into a fresh struct, built by factory, and
+// delivered to the handlers and subscribers.
+// If there are no files to watch, then sources are polled on each tick,
+// so the loader should be built with distinct flag.
+type Watcher[T any] struct {
+	mx          sync.Mutex
+	loader      *Loader
+	factory     func() *T
+	files       []string
+	interval    time.Duration
+	debounce    time.Duration
+	handlers    []func(config *T)
+	errors      []func(err error)
+	subscribers []chan *T
+	stamps      map[string]fileStamp
+	trigger     chan struct{}
+	cancel      context.CancelFunc
+	done        chan struct{}
+}
+
+// Subscribe returns channel, that receives each reloaded config.
+// Slow subscribers get only the latest config.
+// Channel is closed, when watcher is stopped.
+func (that *Watcher[T]) Subscribe() <-chan *T {
+	that.mx.Lock()
+	defer that.mx.Unlock()
+
+	ch := make(chan *T, 1)
+	that.subscribers = append(that.subscribers, ch)
+	return ch
+}
+
+// Notify requests reload regardless of files state.
+func (that *Watcher[T]) Notify() {
+	select {
+	case that.trigger <- struct{}{}:
+	default:
+	}
+}
+
+// Start runs watching in background until Stop is called or ctx is done.
+func (that *Watcher[T]) Start(ctx context.Context) {
+	that.mx.Lock()
+	defer that.mx.Unlock()
+
+	if that.cancel != nil {
+		return
+	}
+
+	ctx, that.cancel = context.WithCancel(ctx)
+	that.done = make(chan struct{})
+	that.stamps = stampsOf(that.files)
+
+	go that.run(ctx, that.done)
+}
+
+// Stop terminates watching and closes all subscriptions.
+func (that *Watcher[T]) Stop() {
+	that.mx.Lock()
+	cancel, done := that.cancel, that.done
+	that.cancel = nil
+	that.mx.Unlock()
+
+	if cancel == nil {
+		return
+	}
+
+	cancel()
+	<-done
+
+	that.mx.Lock()
+	defer that.mx.Unlock()
+
+	for _, ch := range that.subscribers {
+		close(ch)
+	}
+	that.subscribers = nil
+}
+
+// Reload loads config immediately and delivers it.
+// Returns ErrDistinct, when config was not changed.
+func (that *Watcher[T]) Reload() error {
+	config := that.factory()
+	err := that.loader.Load(config)
+	if err != nil {
+		return err
+	}
+
+	that.deliver(config)
+	return nil
+}
+
+func (that *Watcher[T]) run(ctx context.Context, done chan struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(that.interval)
+	defer ticker.Stop()
+
+	timer := time.NewTimer(that.debounce)
+	timer.Stop()
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if that.changed() {
+				timer.Reset(that.debounce)
+			}
+		case <-that.trigger:
+			timer.Reset(that.debounce)
+		case <-timer.C:
+			that.reload()
+		}
+	}
+}
+
+func (that *Watcher[T]) reload() {
+	err := that.Reload()
+	if err == nil || errors.Is(err, ErrDistinct) {
+		return
+	}
+
+	for _, handler := range that.errors {
+		handler(fmt.Errorf("error reload config: %w", err))
+	}
+}
+
+func (that *Watcher[T]) changed() bool {
+	if len(that.files) == 0 {
+		return true
+	}
+
+	stamps := stampsOf(that.files)
+	changed := false
+	for file, stamp := range stamps {
+		if that.stamps[file] != stamp {
+			changed = true
+		}
+	}
+
+	that.stamps = stamps
+	return changed
+}
+
+func (that *Watcher[T]) deliver(config *T) {
+	for _, handler := range that.handlers {
+		handler(config)
+	}
+
+	that.mx.Lock()
+	defer that.mx.Unlock()
+
+	for _, ch := range that.subscribers {
+		select {
+		case <-ch:
+		default:
+		}
+		ch <- config
+	}
+}
+
+type fileStamp struct {
+	exists  bool
+	size    int64
+	modTime time.Time
+}
+
+func stampsOf(files []string) map[string]fileStamp {
+	stamps := make(map[string]fileStamp, len(files))
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			stamps[file] = fileStamp{}
+			continue
+		}
+
+		stamps[file] = fileStamp{
+			exists:  true,
+			size:    info.Size(),
+			modTime: info.ModTime(),
+		}
+	}
+	return stamps
+}
+
+type WatcherBuilder[T any] struct {
+	watcher *Watcher[T]
+	files   bool
+}
+
+func NewWatcherBuilder[T any]() *WatcherBuilder[T] {
+	return &WatcherBuilder[T]{
+		watcher: &Watcher[T]{
+			interval: time.Second,
+			debounce: 100 * time.Millisecond,
+			trigger:  make(chan struct{}, 1),
+		},
+	}
+}
+
+func (that *WatcherBuilder[T]) WithLoader(loader *Loader) *WatcherBuilder[T] {
+	that.watcher.loader = loader
+	return that
+}
+
+// WithFactory defines constructor of fresh config struct (usually with defaults).
+func (that *WatcherBuilder[T]) WithFactory(factory func() *T) *WatcherBuilder[T] {
+	that.watcher.factory = factory
+	return that
+}
+
+// WithFiles overrides list of watched files (by default files of loader are used).
+func (that *WatcherBuilder[T]) WithFiles(files ...string) *WatcherBuilder[T] {
+	that.watcher.files = append(that.watcher.files, files...)
+	that.files = true
+	return that
+}
+
+func (that *WatcherBuilder[T]) WithInterval(interval time.Duration) *WatcherBuilder[T] {
+	that.watcher.interval = interval
+	return that
+}
+
+func (that *WatcherBuilder[T]) WithDebounce(debounce time.Duration) *WatcherBuilder[T] {
+	that.watcher.debounce = debounce
+	return that
+}
+
+func (that *WatcherBuilder[T]) WithHandler(handler func(config *T)) *WatcherBuilder[T] {
+	that.watcher.handlers = append(that.watcher.handlers, handler)
+	return that
+}
+
+func (that *WatcherBuilder[T]) WithErrorHandler(handler func(err error)) *WatcherBuilder[T] {
+	that.watcher.errors = append(that.watcher.errors, handler)
+	return that
+}
+
+func (that *WatcherBuilder[T]) Build() (*Watcher[T], error) {
+	if err := that.checkRequiredFields(); err != nil {
+		return nil, err
+	}
+
+	if !that.files {
+		that.watcher.files = that.watcher.loader.Files()
+	}
+
+	return that.watcher, nil
+}
+
+func (that *WatcherBuilder[T]) checkRequiredFields() error {
+	if that.watcher.loader == nil {
+		return ErrFieldLoaderIsRequired
+	}
+
+	if that.watcher.factory == nil {
+		return ErrFieldFactoryIsRequired
+	}
+
+	if that.watcher.interval <= 0 {
+		return ErrFieldIntervalIsRequired
+	}
+
+	return nil
+}
+
+var (
+	ErrFieldLoaderIsRequired   = fmt.Errorf("Loader is required")
+	ErrFieldFactoryIsRequired  = fmt.Errorf("Factory is required")
+	ErrFieldIntervalIsRequired = fmt.Errorf("Interval is required")
+)
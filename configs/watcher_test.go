@@ -0,0 +1,58 @@
+package configs
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	jsonFetcher "github.com/adverax/metacrm.kernel/access/fetchers/maps/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type watchedConfig struct {
+	Name string `config:"name"`
+	Port int    `config:"port"`
+}
+
+func TestWatcher(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(filename, []byte(`{"name": "first"}`), 0644))
+
+	loader, err := NewFileLoaderBuilder().
+		WithSourceBuilder(func(fetcher Fetcher) Source {
+			return jsonFetcher.New(fetcher)
+		}).
+		WithFile(filename, true).
+		WithDistinct(true).
+		Build()
+	require.NoError(t, err)
+	assert.Equal(t, []string{filename}, loader.Files())
+
+	watcher, err := NewWatcherBuilder[watchedConfig]().
+		WithLoader(loader).
+		WithFactory(func() *watchedConfig { return &watchedConfig{Port: 80} }).
+		WithInterval(10 * time.Millisecond).
+		WithDebounce(20 * time.Millisecond).
+		WithErrorHandler(func(err error) { t.Error(err) }).
+		Build()
+	require.NoError(t, err)
+
+	require.NoError(t, watcher.Reload())
+	assert.ErrorIs(t, watcher.Reload(), ErrDistinct)
+
+	updates := watcher.Subscribe()
+	watcher.Start(context.Background())
+	defer watcher.Stop()
+
+	require.NoError(t, os.WriteFile(filename, []byte(`{"name": "second", "port": 81}`), 0644))
+
+	select {
+	case config := <-updates:
+		assert.Equal(t, &watchedConfig{Name: "second", Port: 81}, config)
+	case <-time.After(2 * time.Second):
+		t.Fatal("config was not reloaded")
+	}
+}
@@ -0,0 +1,171 @@
+package configs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	fileFetchers "github.com/adverax/metacrm.kernel/access/fetchers/bytes/files"
+	memoryFetcher "github.com/adverax/metacrm.kernel/access/fetchers/bytes/memory"
+	"github.com/adverax/metacrm.kernel/containers/maps"
+)
+
+// WritableSource is Source, that can persist data (for example json, yaml or toml file).
+type WritableSource interface {
+	Source
+	Save(data map[string]interface{}) error
+}
+
+// UpdatableSource is WritableSource, that changes data exclusively (read, change and save under the lock).
+type UpdatableSource interface {
+	WritableSource
+	Update(action func(data map[string]interface{}) error) error
+}
+
+// NewFileSource creates writable source of file. Format is detected by extension.
+// File is saved atomically, previous version is kept as "<file>.bak", if backup is enabled.
+// Updates are serialized by the file lock, that is shared with other processes.
+func NewFileSource(file string, backup bool) (UpdatableSource, error) {
+	builder, err := FormatOf(file)
+	if err != nil {
+		return nil, err
+	}
+
+	fetcher, err := fileFetchers.NewBuilder().
+		WithFilename(file).
+		WithBackup(backup).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+
+	source, ok := builder(fetcher).(WritableSource)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrReadOnlySource, file)
+	}
+
+	return &fileSource{
+		WritableSource: source,
+		builder:        builder,
+		fetcher:        fetcher,
+	}, nil
+}
+
+type fileSource struct {
+	WritableSource
+	builder SourceBuilder
+	fetcher *fileFetchers.Fetcher
+}
+
+// Locate delegates to the decorated source.
+func (that *fileSource) Locate(path []string) (file string, line, column int, ok bool) {
+	if locator, ok := that.WritableSource.(Locator); ok {
+		return locator.Locate(path)
+	}
+	return "", 0, 0, false
+}
+
+// Update decodes content of the locked file, applies action and encodes the result.
+func (that *fileSource) Update(action func(data map[string]interface{}) error) error {
+	return that.fetcher.Update(func(content []byte) ([]byte, error) {
+		buffer := memoryFetcher.New(content)
+		source := that.builder(buffer).(WritableSource)
+
+		data, err := source.Fetch()
+		if err != nil {
+			return nil, err
+		}
+		if data == nil {
+			data = make(map[string]interface{})
+		}
+
+		if err := action(data); err != nil {
+			return nil, err
+		}
+
+		if err := source.Save(data); err != nil {
+			return nil, err
+		}
+		return buffer.Fetch()
+	})
+}
+
+// SourceSetter is GetterSetter, that writes changes back into the source.
+// It allows admin tools to persist settings through access.Setter:
+//
+//	source, _ := configs.NewFileSource("config.local.yaml", true)
+//	setter := configs.NewSourceSetter(source)
+//	err := setter.SetProperty(ctx, "$.server.port", 8081)
+//
+// Raw data of the source is changed, so the source should not be decorated
+// by InterpolatedSource or DecryptedSource.
+type SourceSetter struct {
+	mx     sync.Mutex
+	source WritableSource
+}
+
+func NewSourceSetter(source WritableSource) *SourceSetter {
+	return &SourceSetter{
+		source: source,
+	}
+}
+
+func (that *SourceSetter) GetProperty(
+	ctx context.Context,
+	name string,
+) (interface{}, error) {
+	that.mx.Lock()
+	defer that.mx.Unlock()
+
+	data, err := that.source.Fetch()
+	if err != nil {
+		return nil, err
+	}
+
+	return maps.Map(data).GetProperty(ctx, name)
+}
+
+func (that *SourceSetter) SetProperty(
+	ctx context.Context,
+	name string,
+	value interface{},
+) error {
+	return that.Update(ctx, func(data maps.Map) error {
+		return data.SetProperty(ctx, name, value)
+	})
+}
+
+// Update reads data of the source, applies action and saves the result.
+// Data is not saved, if action fails. UpdatableSource is updated under its own lock.
+func (that *SourceSetter) Update(
+	ctx context.Context,
+	action func(data maps.Map) error,
+) error {
+	that.mx.Lock()
+	defer that.mx.Unlock()
+
+	if source, ok := that.source.(UpdatableSource); ok {
+		return source.Update(func(data map[string]interface{}) error {
+			return action(data)
+		})
+	}
+
+	data, err := that.source.Fetch()
+	if err != nil {
+		return err
+	}
+	if data == nil {
+		data = make(map[string]interface{})
+	}
+
+	if err := action(data); err != nil {
+		return err
+	}
+
+	return that.source.Save(data)
+}
+
+var (
+	ErrReadOnlySource = errors.New("read only source")
+)
@@ -0,0 +1,118 @@
+package configs
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/adverax/metacrm.kernel/access"
+	containers "github.com/adverax/metacrm.kernel/containers/maps"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSourceSetter(t *testing.T) {
+	ctx := context.Background()
+	file := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(file, []byte("server:\n  host: localhost\n  port: 8080\n"), 0600))
+
+	source, err := NewFileSource(file, true)
+	require.NoError(t, err)
+
+	setter := NewSourceSetter(source)
+	rw := access.NewReaderWriter(setter)
+	require.NoError(t, rw.SetInteger(ctx, "$.server.port", 8081))
+	require.NoError(t, rw.SetString(ctx, "$.log.level", "debug"))
+
+	port, err := rw.GetInteger(ctx, "$.server.port", 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(8081), port)
+
+	data, err := source.Fetch()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"server": map[string]interface{}{"host": "localhost", "port": 8081},
+		"log":    map[string]interface{}{"level": "debug"},
+	}, data)
+
+	backup, err := os.ReadFile(file + ".bak")
+	require.NoError(t, err)
+	assert.Contains(t, string(backup), "port: 8081")
+	assert.NotContains(t, string(backup), "level")
+
+	info, err := os.Stat(file)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	failure := errors.New("failure")
+	err = setter.Update(ctx, func(data containers.Map) error {
+		data["server"] = nil
+		return failure
+	})
+	assert.ErrorIs(t, err, failure)
+
+	host, err := rw.GetString(ctx, "$.server.host", "")
+	require.NoError(t, err)
+	assert.Equal(t, "localhost", host)
+}
+
+func TestSourceSetter_ConcurrentUpdate(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file locks are not supported")
+	}
+
+	ctx := context.Background()
+	file := filepath.Join(t.TempDir(), "config.json")
+	const workers, updates = 4, 10
+
+	var wg sync.WaitGroup
+	errs := make(chan error, workers)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- func() error {
+				// Setters of separate sources are synchronized by the file lock only
+				source, err := NewFileSource(file, false)
+				if err != nil {
+					return err
+				}
+				setter := NewSourceSetter(source)
+				for j := 0; j < updates; j++ {
+					err := setter.Update(ctx, func(data containers.Map) error {
+						counter, _ := data["counter"].(float64)
+						data["counter"] = counter + 1
+						return nil
+					})
+					if err != nil {
+						return err
+					}
+				}
+				return nil
+			}()
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	source, err := NewFileSource(file, false)
+	require.NoError(t, err)
+	data, err := source.Fetch()
+	require.NoError(t, err)
+	assert.Equal(t, float64(workers*updates), data["counter"])
+}
+
+func TestNewFileSource(t *testing.T) {
+	_, err := NewFileSource(filepath.Join(t.TempDir(), ".env"), false)
+	assert.ErrorIs(t, err, ErrReadOnlySource)
+
+	_, err = NewFileSource(filepath.Join(t.TempDir(), "config.unknown"), false)
+	assert.ErrorIs(t, err, ErrUnknownFormat)
+}
@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/adverax/metacrm.kernel/access/paths"
 	"github.com/adverax/metacrm.kernel/types"
 )
 
@@ -23,34 +24,15 @@ func (that Map) String() string {
 	return fmt.Sprintf("%#v", that)
 }
 
+// GetProperty returns value by name. Names, started with "$", are path expressions
+// (see paths.Compile): "$.address.host", "$.servers[0].host", "$['a.b']".
+// Names, that are not valid expressions, are plain keys.
 func (that Map) GetProperty(
 	_ context.Context,
 	name string,
 ) (interface{}, error) {
-	if strings.HasPrefix(name, "$.") {
-		var path = strings.Split(name[2:], ".")
-
-		if len(path) == 1 {
-			if v, ok := that[name]; ok {
-				return v, nil
-			}
-			return nil, types.ErrNoMatch
-		}
-
-		var val interface{} = that
-		for _, key := range path {
-			if vv, ok := val.(Map); ok {
-				if v, ok := vv[key]; ok {
-					val = v
-				} else {
-					return nil, types.ErrNoMatch
-				}
-			} else {
-				return nil, types.ErrNoMatch
-			}
-		}
-
-		return val, nil
+	if path, ok := pathOf(name); ok {
+		return path.Get(that)
 	}
 
 	if v, ok := that[name]; ok {
@@ -60,45 +42,33 @@ func (that Map) GetProperty(
 	return nil, types.ErrNoMatch
 }
 
+// SetProperty sets value by name. Missing nested maps of path expression are created.
 func (that Map) SetProperty(
 	_ context.Context,
 	name string,
 	value interface{},
 ) error {
-	if strings.HasPrefix(name, "$.") {
-		path := strings.Split(name[2:], ".")
-		if len(path) == 1 {
-			that[name] = value
-			return nil
-		}
-
-		var val interface{} = that
-		for i := 0; i < len(path)-1; i++ {
-			key := path[i]
-			if vv, ok := val.(Map); ok {
-				if v, ok := vv[key]; ok {
-					val = v
-				} else {
-					vv[key] = make(Map)
-					val = vv[key]
-				}
-			} else {
-				return types.ErrNoMatch
-			}
-		}
-
-		if vv, ok := val.(Map); ok {
-			vv[path[len(path)-1]] = value
-			return nil
-		}
-
-		return types.ErrNoMatch
+	if path, ok := pathOf(name); ok {
+		return path.Set(that, value)
 	}
 
 	that[name] = value
 	return nil
 }
 
+// pathOf compiles name, that is path expression. Other names (for example "$merge") are plain keys.
+func pathOf(name string) (*paths.Path, bool) {
+	if !strings.HasPrefix(name, "$") {
+		return nil, false
+	}
+
+	path, err := paths.Compile(name)
+	if err != nil {
+		return nil, false
+	}
+	return path, true
+}
+
 func (that Map) ToBoolean(
 	ctx context.Context,
 	name string,
@@ -277,6 +247,30 @@ func (that Map) GetJson(
 	return types.Json.Get(ctx, that, name, defVal)
 }
 
+func (that Map) GetTime(
+	ctx context.Context,
+	name string,
+	defVal time.Time,
+) (res time.Time, err error) {
+	return types.Time.Get(ctx, that, name, defVal)
+}
+
+func (that Map) GetStrings(
+	ctx context.Context,
+	name string,
+	defVal []string,
+) (res []string, err error) {
+	return types.Strings.Get(ctx, that, name, defVal)
+}
+
+func (that Map) GetMap(
+	ctx context.Context,
+	name string,
+	defVal map[string]interface{},
+) (res map[string]interface{}, err error) {
+	return types.Map.Get(ctx, that, name, defVal)
+}
+
 func (that Map) SetBoolean(
 	ctx context.Context,
 	name string,
@@ -325,6 +319,30 @@ func (that Map) SetJson(
 	return that.SetProperty(ctx, name, value)
 }
 
+func (that Map) SetTime(
+	ctx context.Context,
+	name string,
+	value time.Time,
+) error {
+	return that.SetProperty(ctx, name, value)
+}
+
+func (that Map) SetStrings(
+	ctx context.Context,
+	name string,
+	value []string,
+) error {
+	return that.SetProperty(ctx, name, value)
+}
+
+func (that Map) SetMap(
+	ctx context.Context,
+	name string,
+	value map[string]interface{},
+) error {
+	return that.SetProperty(ctx, name, value)
+}
+
 // Scope is routine, that allow access to the branch of base Map as sub Map.
 func (that Map) Scope(name string) Map {
 	if mm, ok := that[name]; ok {
@@ -203,6 +203,13 @@ func GetVariable[T any](ctx context.Context, key string) (val T, err error) {
 	return access.GetValue[T](ctx, vars, key)
 }
 
+// GetVariableOr - get variable from application context or default value, if variable is missing
+func GetVariableOr[T any](ctx context.Context, key string, defVal T) (val T, err error) {
+	app := GetAppFromContext(ctx)
+	vars := app.Variables()
+	return access.GetValueOr[T](ctx, vars, key, defVal)
+}
+
 // SetVariable - set variable to application context
 func SetVariable[T any](ctx context.Context, key string, val T) error {
 	app := GetAppFromContext(ctx)
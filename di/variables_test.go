@@ -0,0 +1,27 @@
+package di
+
+import (
+	"context"
+	"testing"
+
+	"github.com/adverax/metacrm.kernel/access"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetVariable(t *testing.T) {
+	ctx := SetAppToContext(context.Background(), newApp(AppOptions{}))
+	require.NoError(t, SetVariable(ctx, "port", "8080"))
+	require.NoError(t, SetVariable(ctx, "name", "My App"))
+
+	port, err := GetVariable[int](ctx, "port")
+	require.NoError(t, err)
+	assert.Equal(t, 8080, port)
+
+	timeout, err := GetVariableOr[int](ctx, "timeout", 30)
+	require.NoError(t, err)
+	assert.Equal(t, 30, timeout)
+
+	_, err = GetVariable[int](ctx, "name")
+	assert.ErrorIs(t, err, access.ErrWrongType)
+}
@@ -32,6 +32,8 @@ const (
 	DateTimeFormat = "2006-01-02 15:04:05"
 )
 
+var timeLayouts = []string{DateTimeFormat, time.RFC3339Nano, DateFormat}
+
 func ToString(val interface{}) (res string, valid bool) {
 	switch v := val.(type) {
 	case string:
@@ -88,11 +90,12 @@ func ToString(val interface{}) (res string, valid bool) {
 func ToTime(val interface{}) (res time.Time, valid bool) {
 	switch v := val.(type) {
 	case string:
-		val, err := time.ParseInLocation(DateTimeFormat, v, time.UTC)
-		if err != nil {
-			return
+		for _, layout := range timeLayouts {
+			if val, err := time.ParseInLocation(layout, v, time.UTC); err == nil {
+				return val, true
+			}
 		}
-		return val, true
+		return
 	case int64:
 		return time.Unix(v, 0), true
 	case uint64:
@@ -983,3 +986,71 @@ func To(value interface{}, to reflect.Type) (reflect.Value, bool) {
 
 	return reflect.Value{}, false
 }
+
+// As converts value into type T.
+// Scalars are converted by the functions To..., json values are decoded into composite types.
+func As[T any](value interface{}) (res T, valid bool) {
+	if v, ok := value.(T); ok {
+		return v, true
+	}
+
+	if value == nil {
+		return res, false
+	}
+
+	var v interface{}
+	switch any(res).(type) {
+	case bool:
+		v, valid = ToBoolean(value)
+	case int:
+		v, valid = ToInt(value)
+	case int64:
+		v, valid = ToInt64(value)
+	case uint64:
+		v, valid = ToUint64(value)
+	case float64:
+		v, valid = ToFloat64(value)
+	case string:
+		v, valid = ToString(value)
+	case time.Duration:
+		v, valid = ToDuration(value)
+	case time.Time:
+		v, valid = ToTime(value)
+	case json.RawMessage:
+		v, valid = ToJson(value)
+	default:
+		if rv, ok := To(value, reflect.TypeOf(&res).Elem()); ok {
+			return rv.Interface().(T), true
+		}
+		return asComposite[T](value)
+	}
+
+	if !valid {
+		return res, false
+	}
+	return v.(T), true
+}
+
+// asComposite converts value into slice, map or struct through json.
+func asComposite[T any](value interface{}) (res T, valid bool) {
+	var data []byte
+	switch v := value.(type) {
+	case json.RawMessage:
+		data = v
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		var err error
+		data, err = json.Marshal(v)
+		if err != nil {
+			return res, false
+		}
+	}
+
+	if err := json.Unmarshal(data, &res); err != nil {
+		return res, false
+	}
+	return res, true
+}
@@ -3,6 +3,7 @@ package convert
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -1257,3 +1258,29 @@ func TestConvertTo(t *testing.T) {
 		})
 	}
 }
+
+func TestAs(t *testing.T) {
+	type address struct {
+		Host string `json:"host"`
+		Port int    `json:"port"`
+	}
+
+	i, ok := As[int64]("42")
+	assert.True(t, ok)
+	assert.Equal(t, int64(42), i)
+
+	d, ok := As[time.Duration]("5s")
+	assert.True(t, ok)
+	assert.Equal(t, 5*time.Second, d)
+
+	a, ok := As[address](`{"host":"localhost","port":80}`)
+	assert.True(t, ok)
+	assert.Equal(t, address{Host: "localhost", Port: 80}, a)
+
+	a, ok = As[address](map[string]interface{}{"host": "example.com"})
+	assert.True(t, ok)
+	assert.Equal(t, address{Host: "example.com"}, a)
+
+	_, ok = As[int64]("abc")
+	assert.False(t, ok)
+}
@@ -35,6 +35,9 @@ var (
 	String   = &StringType{}
 	Duration = &DurationType{}
 	Json     = &JsonType{}
+	Time     = &TimeType{}
+	Strings  = &StringsType{}
+	Map      = &MapType{}
 )
 
 var (
@@ -0,0 +1,57 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/adverax/metacrm.kernel/types/convert"
+)
+
+type MapType struct{}
+
+func (that *MapType) Is(value interface{}) bool {
+	v := reflect.ValueOf(value)
+	return v.Kind() == reflect.Map && v.Type().Key().Kind() == reflect.String
+}
+
+func (that *MapType) IsAll(values []interface{}) bool {
+	return IsAll(values, that)
+}
+
+func (that *MapType) Get(ctx context.Context, getter Getter, name string, defVal map[string]interface{}) (res map[string]interface{}, err error) {
+	val, err := getter.GetProperty(ctx, name)
+	if err != nil {
+		if errors.Is(err, ErrNoMatch) {
+			return defVal, nil
+		}
+		return
+	}
+	if val == nil {
+		return defVal, nil
+	}
+	if res, ok := that.TryCast(val); ok {
+		return res, nil
+	}
+	return nil, fmt.Errorf("can not convert value %v into map with key %q", val, name)
+}
+
+func (that *MapType) TryCast(value interface{}) (map[string]interface{}, bool) {
+	return convert.As[map[string]interface{}](value)
+}
+
+func (that *MapType) Cast(v interface{}, defaults map[string]interface{}) map[string]interface{} {
+	if vv, ok := that.TryCast(v); ok {
+		return vv
+	}
+	return defaults
+}
+
+func (that *MapType) CastAll(values []interface{}) []map[string]interface{} {
+	result := make([]map[string]interface{}, len(values))
+	for i, value := range values {
+		result[i] = that.Cast(value, nil)
+	}
+	return result
+}
@@ -0,0 +1,96 @@
+package types
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/adverax/metacrm.kernel/types/convert"
+)
+
+// StringsType is list of strings. String value is treated as comma separated list.
+type StringsType struct{}
+
+func (that *StringsType) Is(value interface{}) bool {
+	switch v := value.(type) {
+	case []string:
+	case []interface{}:
+		return IsAll(v, String)
+	default:
+		return false
+	}
+
+	return true
+}
+
+func (that *StringsType) IsAll(values []interface{}) bool {
+	return IsAll(values, that)
+}
+
+func (that *StringsType) Get(ctx context.Context, getter Getter, name string, defVal []string) (res []string, err error) {
+	val, err := getter.GetProperty(ctx, name)
+	if err != nil {
+		if errors.Is(err, ErrNoMatch) {
+			return defVal, nil
+		}
+		return
+	}
+	if val == nil {
+		return defVal, nil
+	}
+	if res, ok := that.TryCast(val); ok {
+		return res, nil
+	}
+	return nil, fmt.Errorf("can not convert value %v into strings with key %q", val, name)
+}
+
+func (that *StringsType) TryCast(value interface{}) ([]string, bool) {
+	switch v := value.(type) {
+	case []string:
+		return v, true
+	case []interface{}:
+		res := make([]string, len(v))
+		for i, item := range v {
+			s, ok := String.TryCast(item)
+			if !ok {
+				return nil, false
+			}
+			res[i] = s
+		}
+		return res, true
+	case string:
+		if v == "" {
+			return []string{}, true
+		}
+		res := strings.Split(v, ",")
+		for i, item := range res {
+			res[i] = strings.TrimSpace(item)
+		}
+		return res, true
+	case json.RawMessage:
+		var res []string
+		if err := json.Unmarshal(v, &res); err != nil {
+			return nil, false
+		}
+		return res, true
+	default:
+		return convert.As[[]string](value)
+	}
+}
+
+func (that *StringsType) Cast(v interface{}, defaults []string) []string {
+	if vv, ok := that.TryCast(v); ok {
+		return vv
+	}
+	return defaults
+}
+
+func (that *StringsType) CastAll(values []interface{}) [][]string {
+	result := make([][]string, len(values))
+	for i, value := range values {
+		result[i] = that.Cast(value, nil)
+	}
+	return result
+}
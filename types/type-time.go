@@ -0,0 +1,66 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/adverax/metacrm.kernel/types/convert"
+)
+
+type TimeType struct{}
+
+func (that *TimeType) Is(value interface{}) bool {
+	switch value.(type) {
+	case time.Time:
+	case string:
+	default:
+		return false
+	}
+
+	return true
+}
+
+func (that *TimeType) IsAll(values []interface{}) bool {
+	return IsAll(values, that)
+}
+
+func (that *TimeType) Get(ctx context.Context, getter Getter, name string, defVal time.Time) (res time.Time, err error) {
+	val, err := getter.GetProperty(ctx, name)
+	if err != nil {
+		if errors.Is(err, ErrNoMatch) {
+			return defVal, nil
+		}
+		return
+	}
+	if val == nil {
+		return defVal, nil
+	}
+	if res, ok := that.TryCast(val); ok {
+		return res, nil
+	}
+	return time.Time{}, fmt.Errorf("can not convert value %v into time with key %q", val, name)
+}
+
+func (that *TimeType) TryCast(value interface{}) (time.Time, bool) {
+	if v, ok := value.(time.Time); ok {
+		return v, true
+	}
+	return convert.ToTime(value)
+}
+
+func (that *TimeType) Cast(v interface{}, defaults time.Time) time.Time {
+	if vv, ok := that.TryCast(v); ok {
+		return vv
+	}
+	return defaults
+}
+
+func (that *TimeType) CastAll(values []interface{}) []time.Time {
+	result := make([]time.Time, len(values))
+	for i, value := range values {
+		result[i] = that.Cast(value, time.Time{})
+	}
+	return result
+}